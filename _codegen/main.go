@@ -20,6 +20,7 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -131,8 +132,14 @@ func analyzeCode(scope *types.Scope, docs *doc.Package) (imports.Importer, []tes
 
 	importer := imports.New(*outputPkg)
 	var funcs []testFunc
-	// Go through all the top level functions
-	for _, fdocs := range docs.Funcs {
+	// Go through all the top level functions, including the ones go/doc
+	// lists with the type they return
+	allFuncs := append([]*doc.Func(nil), docs.Funcs...)
+	for _, tdocs := range docs.Types {
+		allFuncs = append(allFuncs, tdocs.Funcs...)
+	}
+	sort.Slice(allFuncs, func(i, j int) bool { return allFuncs[i].Name < allFuncs[j].Name })
+	for _, fdocs := range allFuncs {
 		// Find the function
 		obj := scope.Lookup(fdocs.Name)
 
@@ -158,6 +165,12 @@ func analyzeCode(scope *types.Scope, docs *doc.Package) (imports.Importer, []tes
 			continue
 		}
 
+		// Check the last result is the bool reporting the assertion result
+		results := sig.Results()
+		if results.Len() == 0 || !types.Identical(results.At(results.Len()-1).Type(), types.Typ[types.Bool]) {
+			continue
+		}
+
 		// Skip functions ending with f
 		if strings.HasSuffix(fdocs.Name, "f") && !*includeF {
 			continue
@@ -165,6 +178,7 @@ func analyzeCode(scope *types.Scope, docs *doc.Package) (imports.Importer, []tes
 
 		funcs = append(funcs, testFunc{*outputPkg, fdocs, fn})
 		importer.AddImportsFrom(sig.Params())
+		importer.AddImportsFrom(sig.Results())
 	}
 	return importer, funcs, nil
 }
@@ -275,6 +289,35 @@ func (f *testFunc) ForwardedParams() string {
 	return p.String()
 }
 
+// Results returns the results of the assertion function: either a bool, or a
+// value followed by a bool.
+func (f *testFunc) Results() string {
+	results := f.TypeInfo.Type().(*types.Signature).Results()
+	if results.Len() == 1 {
+		return types.TypeString(results.At(0).Type(), f.Qualifier)
+	}
+	r := make([]string, results.Len())
+	for i := range r {
+		r[i] = types.TypeString(results.At(i).Type(), f.Qualifier)
+	}
+	return "(" + strings.Join(r, ", ") + ")"
+}
+
+// ReturnsValue reports whether the assertion function returns a value in
+// addition to the bool.
+func (f *testFunc) ReturnsValue() bool {
+	return f.TypeInfo.Type().(*types.Signature).Results().Len() > 1
+}
+
+// RequireResults returns the results of the require variant of the assertion
+// function, which drops the bool.
+func (f *testFunc) RequireResults() string {
+	if !f.ReturnsValue() {
+		return ""
+	}
+	return types.TypeString(f.TypeInfo.Type().(*types.Signature).Results().At(0).Type(), f.Qualifier)
+}
+
 func (f *testFunc) ParamsFormat() string {
 	return strings.Replace(f.Params(), "msgAndArgs", "msg string, args", 1)
 }
@@ -362,6 +405,6 @@ import (
 `
 
 var funcTemplate = `{{.Comment}}
-func (fwd *AssertionsForwarder) {{.DocInfo.Name}}({{.Params}}) bool {
+func (fwd *AssertionsForwarder) {{.DocInfo.Name}}({{.Params}}) {{.Results}} {
 	return assert.{{.DocInfo.Name}}({{.ForwardedParams}})
 }`
@@ -0,0 +1,259 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// hexdumpRowSize is the number of bytes shown on each row of a hexdump.
+	hexdumpRowSize = 16
+	// hexdumpMaxRows is the number of rows shown by hexdumpDiff from the
+	// first difference on.
+	hexdumpMaxRows = 8
+	// readerChunkSize is the number of bytes read at once from each stream by
+	// ReaderEquals and ReaderContains. It is a multiple of hexdumpRowSize so
+	// that the rows of a chunk line up with the offsets in the stream.
+	readerChunkSize = 32 << 10
+)
+
+// hexdumpRow formats row, which starts at offset, like hexdump -C does. Short
+// rows are padded so that the printable characters stay aligned.
+func hexdumpRow(offset int64, row []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%08x ", offset)
+	for i := 0; i < hexdumpRowSize; i++ {
+		if i == hexdumpRowSize/2 {
+			b.WriteByte(' ')
+		}
+		if i < len(row) {
+			fmt.Fprintf(&b, " %02x", row[i])
+		} else {
+			b.WriteString("   ")
+		}
+	}
+	b.WriteString("  |")
+	for _, c := range row {
+		if c < 32 || c > 126 {
+			c = '.'
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte('|')
+	return b.String()
+}
+
+// hexdumpColumn returns the column of the hex digits of the byte at index i
+// of a row formatted by hexdumpRow.
+func hexdumpColumn(i int) int {
+	col := 10 + 3*i
+	if i >= hexdumpRowSize/2 {
+		col++
+	}
+	return col
+}
+
+// hexdumpRowAt returns the row of data that starts at index start, if any.
+func hexdumpRowAt(data []byte, start int) ([]byte, bool) {
+	if start >= len(data) {
+		return nil, false
+	}
+	end := start + hexdumpRowSize
+	if end > len(data) {
+		end = len(data)
+	}
+	return data[start:end], true
+}
+
+// hexdumpDiff returns a hexdump of expected and actual around first, the index
+// of their first difference. Rows that are equal are shown once, while rows
+// that differ are shown for each side, prefixed with - and +. The byte at first
+// is marked with a caret. base is the offset of the data in its stream.
+func hexdumpDiff(expected, actual []byte, base int64, first int) string {
+	firstRow := first - first%hexdumpRowSize
+	start := firstRow - hexdumpRowSize
+	if start < 0 {
+		start = 0
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\nDiff:\n--- Expected\n+++ Actual\n")
+	for row := start; row <= firstRow+(hexdumpMaxRows-1)*hexdumpRowSize; row += hexdumpRowSize {
+		expectedRow, hasExpected := hexdumpRowAt(expected, row)
+		actualRow, hasActual := hexdumpRowAt(actual, row)
+		if !hasExpected && !hasActual {
+			break
+		}
+		offset := base + int64(row)
+
+		if hasExpected && hasActual && bytes.Equal(expectedRow, actualRow) {
+			b.WriteString("  " + hexdumpRow(offset, expectedRow) + "\n")
+			continue
+		}
+		if hasExpected {
+			b.WriteString("- " + hexdumpRow(offset, expectedRow) + "\n")
+		}
+		if hasActual {
+			b.WriteString("+ " + hexdumpRow(offset, actualRow) + "\n")
+		}
+		if row == firstRow {
+			b.WriteString(strings.Repeat(" ", 2+hexdumpColumn(first-firstRow)) + "^^\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// firstDifference returns the index of the first byte that differs between a
+// and b, or the length of the shortest one if it is a prefix of the other.
+// It returns -1 if they are equal.
+func firstDifference(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return len(a)
+		}
+		return len(b)
+	}
+	return -1
+}
+
+// BytesEqual asserts that two byte slices are equal. On failure, it shows a
+// hexdump of both around the first difference, which is easier to read than
+// the diff of Equal for binary data.
+//
+//	assert.BytesEqual(t, []byte{0x01, 0x02}, frame)
+func BytesEqual(t TestingT, expected, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	first := firstDifference(expected, actual)
+	if first < 0 {
+		return true
+	}
+
+	return Fail(t, fmt.Sprintf("Bytes not equal, first difference at offset %d (0x%x):\n"+
+		"expected: %d bytes\n"+
+		"actual  : %d bytes", first, first, len(expected), len(actual))+hexdumpDiff(expected, actual, 0, first), msgAndArgs...)
+}
+
+// streamBytes returns the content of s if it is a string or a []byte.
+func streamBytes(s interface{}) ([]byte, bool) {
+	switch v := s.(type) {
+	case []byte:
+		return v, true
+	case string:
+		return []byte(v), true
+	default:
+		return nil, false
+	}
+}
+
+// readChunk reads from r until buf is full or r is exhausted.
+func readChunk(r io.Reader, buf []byte) (int, error) {
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return n, err
+}
+
+// ReaderEquals asserts that the content read from actual is equal to expected,
+// which can be a string, a []byte or an io.Reader. Both are read in chunks, so
+// that large streams are never held in memory as a whole. On failure, it shows
+// a hexdump of both around the first difference. Readers are not closed.
+//
+//	assert.ReaderEquals(t, golden, resp.Body)
+func ReaderEquals(t TestingT, expected interface{}, actual io.Reader, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	expectedReader, ok := expected.(io.Reader)
+	if !ok {
+		content, ok := streamBytes(expected)
+		if !ok {
+			return Fail(t, fmt.Sprintf("Expected must be a string, a []byte or an io.Reader, got %T", expected), msgAndArgs...)
+		}
+		expectedReader = bytes.NewReader(content)
+	}
+
+	expectedBuf := make([]byte, readerChunkSize)
+	actualBuf := make([]byte, readerChunkSize)
+	var offset int64
+	for {
+		expectedN, err := readChunk(expectedReader, expectedBuf)
+		if err != nil {
+			return Fail(t, fmt.Sprintf("error when reading expected stream at offset %d: %s", offset+int64(expectedN), err), msgAndArgs...)
+		}
+		actualN, err := readChunk(actual, actualBuf)
+		if err != nil {
+			return Fail(t, fmt.Sprintf("error when reading actual stream at offset %d: %s", offset+int64(actualN), err), msgAndArgs...)
+		}
+
+		if first := firstDifference(expectedBuf[:expectedN], actualBuf[:actualN]); first >= 0 {
+			var ended string
+			switch {
+			case expectedN < actualN && first == expectedN:
+				ended = "\nexpected stream ends first"
+			case actualN < expectedN && first == actualN:
+				ended = "\nactual stream ends first"
+			}
+			return Fail(t, fmt.Sprintf("Streams not equal, first difference at offset %d (0x%x)%s", offset+int64(first), offset+int64(first), ended)+
+				hexdumpDiff(expectedBuf[:expectedN], actualBuf[:actualN], offset, first), msgAndArgs...)
+		}
+		if expectedN < readerChunkSize {
+			return true
+		}
+		offset += int64(expectedN)
+	}
+}
+
+// ReaderContains asserts that the content read from r contains the specified
+// string or []byte. r is read in chunks, so that large streams are never held
+// in memory as a whole, and reading stops as soon as contains is found.
+//
+//	assert.ReaderContains(t, resp.Body, "<title>Home</title>")
+func ReaderContains(t TestingT, r io.Reader, contains interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	sub, ok := streamBytes(contains)
+	if !ok {
+		return Fail(t, fmt.Sprintf("Contains must be a string or a []byte, got %T", contains), msgAndArgs...)
+	}
+
+	// Keep the end of the previous chunk, so that matches that span two
+	// chunks are found
+	overlap := len(sub) - 1
+	if overlap < 0 {
+		overlap = 0
+	}
+	buf := make([]byte, overlap+readerChunkSize)
+	kept := 0
+	var read int64
+	for {
+		n, err := readChunk(r, buf[kept:])
+		read += int64(n)
+		if err != nil {
+			return Fail(t, fmt.Sprintf("error when reading stream at offset %d: %s", read, err), msgAndArgs...)
+		}
+		data := buf[:kept+n]
+		if bytes.Contains(data, sub) {
+			return true
+		}
+		if kept+n < len(buf) {
+			break
+		}
+		kept = copy(buf, data[len(data)-overlap:])
+	}
+
+	return Fail(t, fmt.Sprintf("Stream of %d bytes does not contain %s", read, truncatingFormat("%q", sub)), msgAndArgs...)
+}
@@ -0,0 +1,109 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestHexdumpRow(t *testing.T) {
+	t.Parallel()
+
+	Equal(t, "00000010  48 65 6c 6c 6f 20 57 6f  72 6c 64 21 00 01 02 03  |Hello World!....|",
+		hexdumpRow(16, []byte("Hello World!\x00\x01\x02\x03")))
+	Equal(t, "00000000  48 69                                             |Hi|",
+		hexdumpRow(0, []byte("Hi")))
+}
+
+func TestBytesEqualHexdump(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, BytesEqual(mockT, nil, []byte{}))
+	True(t, BytesEqual(mockT, []byte("frame"), []byte("frame")))
+
+	expected := []byte("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	actual := append([]byte{}, expected...)
+	actual[37] = 'X'
+	res := BytesEqual(mockT, expected, actual)
+	mockT.checkResultAndErrMsg(t, false, res, "Bytes not equal, first difference at offset 37 (0x25):\n"+
+		"expected: 64 bytes\n"+
+		"actual  : 64 bytes\n"+
+		"\n"+
+		"Diff:\n"+
+		"--- Expected\n"+
+		"+++ Actual\n"+
+		"  00000010  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|\n"+
+		"- 00000020  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|\n"+
+		"+ 00000020  30 31 32 33 34 58 36 37  38 39 61 62 63 64 65 66  |01234X6789abcdef|\n"+
+		"                           ^^\n"+
+		"  00000030  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|\n")
+
+	res = BytesEqual(mockT, []byte{0x01, 0x02, 0x03}, []byte{0x01, 0x02})
+	mockT.checkResultAndErrMsg(t, false, res, "Bytes not equal, first difference at offset 2 (0x2):\n"+
+		"expected: 3 bytes\n"+
+		"actual  : 2 bytes\n"+
+		"\n"+
+		"Diff:\n"+
+		"--- Expected\n"+
+		"+++ Actual\n"+
+		"- 00000000  01 02 03                                          |...|\n"+
+		"+ 00000000  01 02                                             |..|\n"+
+		"                  ^^\n")
+}
+
+func TestReaderEquals(t *testing.T) {
+	t.Parallel()
+
+	large := bytes.Repeat([]byte("0123456789abcdef"), readerChunkSize/8+3)
+
+	mockT := new(captureTestingT)
+	True(t, ReaderEquals(mockT, "", strings.NewReader("")))
+	True(t, ReaderEquals(mockT, "hello", strings.NewReader("hello")))
+	True(t, ReaderEquals(mockT, []byte("hello"), iotest.OneByteReader(strings.NewReader("hello"))))
+	True(t, ReaderEquals(mockT, bytes.NewReader(large), iotest.HalfReader(bytes.NewReader(large))))
+
+	changed := append([]byte{}, large...)
+	changed[readerChunkSize+20] = 'X'
+	res := ReaderEquals(mockT, bytes.NewReader(large), bytes.NewReader(changed))
+	False(t, res)
+	Contains(t, mockT.msg, "Streams not equal, first difference at offset 32788 (0x8014)")
+	Contains(t, mockT.msg, "+ 00008010  30 31 32 33 58 35 36 37  38 39 61 62 63 64 65 66  |0123X56789abcdef|")
+
+	res = ReaderEquals(mockT, "hello", strings.NewReader("hello world"))
+	False(t, res)
+	Contains(t, mockT.msg, "Streams not equal, first difference at offset 5 (0x5)")
+	Contains(t, mockT.msg, "expected stream ends first")
+
+	res = ReaderEquals(mockT, "hello", iotest.ErrReader(errors.New("broken")))
+	mockT.checkResultAndErrMsg(t, false, res, "error when reading actual stream at offset 0: broken\n")
+
+	res = ReaderEquals(mockT, 42, strings.NewReader("42"))
+	mockT.checkResultAndErrMsg(t, false, res, "Expected must be a string, a []byte or an io.Reader, got int\n")
+}
+
+func TestReaderContains(t *testing.T) {
+	t.Parallel()
+
+	// The needle spans the first two chunks
+	large := make([]byte, 2*readerChunkSize)
+	copy(large[readerChunkSize-3:], "needle")
+
+	mockT := new(captureTestingT)
+	True(t, ReaderContains(mockT, strings.NewReader("hello world"), "o w"))
+	True(t, ReaderContains(mockT, strings.NewReader(""), ""))
+	True(t, ReaderContains(mockT, iotest.OneByteReader(bytes.NewReader(large)), []byte("needle")))
+	True(t, ReaderContains(mockT, io.MultiReader(bytes.NewReader(large), iotest.ErrReader(errors.New("unread"))), "needle"))
+
+	res := ReaderContains(mockT, bytes.NewReader(large), "pin")
+	mockT.checkResultAndErrMsg(t, false, res, "Stream of 65536 bytes does not contain \"pin\"\n")
+
+	res = ReaderContains(mockT, iotest.ErrReader(errors.New("broken")), "pin")
+	mockT.checkResultAndErrMsg(t, false, res, "error when reading stream at offset 0: broken\n")
+
+	res = ReaderContains(mockT, strings.NewReader("hello"), 'h')
+	mockT.checkResultAndErrMsg(t, false, res, "Contains must be a string or a []byte, got int32\n")
+}
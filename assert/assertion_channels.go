@@ -0,0 +1,191 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// receivableChan returns the value of ch, and whether ch is a channel that
+// values can be received from.
+func receivableChan(ch interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan || v.Type().ChanDir()&reflect.RecvDir == 0 {
+		return v, false
+	}
+	return v, true
+}
+
+// receiveWithin waits up to timeout to receive a value from ch. closed is true
+// if ch was closed instead.
+func receiveWithin(ch reflect.Value, timeout time.Duration) (value reflect.Value, received, closed bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	chosen, value, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)},
+	})
+	if chosen == 1 {
+		return reflect.Value{}, false, false
+	}
+	return value, ok, !ok
+}
+
+// Receives asserts that a value is received from the channel ch within
+// timeout. It returns the received value, and whether the assertion was
+// successful.
+//
+//	assert.Receives(t, results, time.Second)
+func Receives(t TestingT, ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) (interface{}, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	chValue, ok := receivableChan(ch)
+	if !ok {
+		return nil, Fail(t, fmt.Sprintf("%T is not a channel that can be received from", ch), msgAndArgs...)
+	}
+
+	value, received, closed := receiveWithin(chValue, timeout)
+	if closed {
+		return nil, Fail(t, "Channel was closed before a value was received", msgAndArgs...)
+	}
+	if !received {
+		return nil, Fail(t, fmt.Sprintf("No value received from channel within %v", timeout), msgAndArgs...)
+	}
+
+	return value.Interface(), true
+}
+
+// ReceivesValue asserts that a value is received from the channel ch within
+// timeout, and that it is equal to expected.
+//
+//	assert.ReceivesValue(t, results, "done", time.Second)
+func ReceivesValue(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	actual, ok := Receives(t, ch, timeout, msgAndArgs...)
+	if !ok {
+		return false
+	}
+
+	if !ObjectsAreEqual(expected, actual) {
+		diff := diff(expected, actual)
+		expected, actual = formatUnequalValues(expected, actual)
+		return Fail(t, fmt.Sprintf("Received value not equal: \n"+
+			"expected: %s\n"+
+			"actual  : %s%s", expected, actual, diff), msgAndArgs...)
+	}
+
+	return true
+}
+
+// NeverReceives asserts that no value is received from the channel ch, and
+// that it is not closed, during the given duration.
+//
+//	assert.NeverReceives(t, errs, 100*time.Millisecond)
+func NeverReceives(t TestingT, ch interface{}, duration time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	chValue, ok := receivableChan(ch)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a channel that can be received from", ch), msgAndArgs...)
+	}
+
+	value, received, closed := receiveWithin(chValue, duration)
+	if closed {
+		return Fail(t, "Channel was closed", msgAndArgs...)
+	}
+	if received {
+		return Fail(t, fmt.Sprintf("Should not receive a value from channel, but received %s", truncatingFormat("%#v", value.Interface())), msgAndArgs...)
+	}
+
+	return true
+}
+
+// IsClosed asserts that the channel ch is closed and that no values are left
+// in its buffer. It does not wait, and values left in the buffer are not
+// consumed. Telling whether ch is closed takes a receive though: a value sent
+// at that moment, such as by a sender blocked on an unbuffered channel, is
+// consumed and reported as a failure.
+//
+//	assert.IsClosed(t, done)
+func IsClosed(t TestingT, ch interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	chValue, ok := receivableChan(ch)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a channel that can be received from", ch), msgAndArgs...)
+	}
+	if n := chValue.Len(); n > 0 {
+		return Fail(t, fmt.Sprintf("Channel should be closed, but still has %d buffered value(s)", n), msgAndArgs...)
+	}
+
+	value, received := chValue.TryRecv()
+	if !value.IsValid() {
+		return Fail(t, "Channel should be closed", msgAndArgs...)
+	}
+	if received {
+		return Fail(t, fmt.Sprintf("Channel should be closed, but received %s", truncatingFormat("%#v", value.Interface())), msgAndArgs...)
+	}
+
+	return true
+}
+
+// NotClosed asserts that the channel ch is not closed. A closed channel that
+// still has values in its buffer is not considered closed yet. It does not
+// wait, and values left in the buffer are not consumed. Telling whether ch is
+// closed takes a receive though: a value sent at that moment, such as by a
+// sender blocked on an unbuffered channel, is consumed and reported as a
+// failure, so NotClosed is meant for channels without pending senders.
+//
+//	assert.NotClosed(t, done)
+func NotClosed(t TestingT, ch interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	chValue, ok := receivableChan(ch)
+	if !ok {
+		return Fail(t, fmt.Sprintf("%T is not a channel that can be received from", ch), msgAndArgs...)
+	}
+	if chValue.Len() > 0 {
+		return true
+	}
+
+	value, received := chValue.TryRecv()
+	if received {
+		return Fail(t, fmt.Sprintf("Channel is not closed, but checking it consumed %s", truncatingFormat("%#v", value.Interface())), msgAndArgs...)
+	}
+	if value.IsValid() {
+		return Fail(t, "Channel should not be closed", msgAndArgs...)
+	}
+
+	return true
+}
+
+// ChanEmpty asserts that there are no values in the buffer of the channel ch.
+//
+//	assert.ChanEmpty(t, events)
+func ChanEmpty(t TestingT, ch interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	chValue := reflect.ValueOf(ch)
+	if chValue.Kind() != reflect.Chan {
+		return Fail(t, fmt.Sprintf("%T is not a channel", ch), msgAndArgs...)
+	}
+	if n := chValue.Len(); n > 0 {
+		return Fail(t, fmt.Sprintf("Channel should be empty, but has %d buffered value(s)", n), msgAndArgs...)
+	}
+
+	return true
+}
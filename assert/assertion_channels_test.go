@@ -0,0 +1,152 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReceives(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+
+	ch := make(chan string, 1)
+	ch <- "done"
+	value, ok := Receives(mockT, ch, 10*time.Millisecond)
+	True(t, ok)
+	Equal(t, "done", value)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ch <- "later"
+	}()
+	value, ok = Receives(mockT, (<-chan string)(ch), time.Second)
+	True(t, ok)
+	Equal(t, "later", value)
+
+	value, ok = Receives(mockT, ch, 10*time.Millisecond)
+	mockT.checkResultAndErrMsg(t, false, ok, "No value received from channel within 10ms\n")
+	Nil(t, value)
+
+	close(ch)
+	_, ok = Receives(mockT, ch, 10*time.Millisecond)
+	mockT.checkResultAndErrMsg(t, false, ok, "Channel was closed before a value was received\n")
+
+	_, ok = Receives(mockT, make(chan<- int), 10*time.Millisecond)
+	mockT.checkResultAndErrMsg(t, false, ok, "chan<- int is not a channel that can be received from\n")
+
+	_, ok = Receives(mockT, "not a channel", 10*time.Millisecond)
+	mockT.checkResultAndErrMsg(t, false, ok, "string is not a channel that can be received from\n")
+}
+
+func TestReceivesValue(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	True(t, ReceivesValue(mockT, ch, 1, 10*time.Millisecond))
+	False(t, ReceivesValue(mockT, ch, 1, 10*time.Millisecond))
+	False(t, ReceivesValue(mockT, ch, 3, 10*time.Millisecond))
+}
+
+func TestNeverReceives(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+
+	ch := make(chan int, 1)
+	True(t, NeverReceives(mockT, ch, 10*time.Millisecond))
+
+	ch <- 42
+	res := NeverReceives(mockT, ch, 10*time.Millisecond)
+	mockT.checkResultAndErrMsg(t, false, res, "Should not receive a value from channel, but received 42\n")
+
+	close(ch)
+	res = NeverReceives(mockT, ch, 10*time.Millisecond)
+	mockT.checkResultAndErrMsg(t, false, res, "Channel was closed\n")
+}
+
+func TestIsClosedNotClosed(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+
+	ch := make(chan int, 1)
+	False(t, IsClosed(mockT, ch))
+	True(t, NotClosed(mockT, ch))
+
+	ch <- 1
+	close(ch)
+	False(t, IsClosed(mockT, ch), "a closed channel with buffered values is not closed yet")
+	True(t, NotClosed(mockT, ch), "a closed channel with buffered values is not closed yet")
+	Len(t, ch, 1, "buffered values should not be consumed")
+
+	<-ch
+	True(t, IsClosed(mockT, ch))
+	False(t, NotClosed(mockT, ch))
+
+	var nilChan chan int
+	False(t, IsClosed(mockT, nilChan))
+	True(t, NotClosed(mockT, nilChan))
+
+	False(t, IsClosed(mockT, 1))
+	False(t, NotClosed(mockT, 1))
+}
+
+func TestIsClosedNotClosedPendingSender(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		name   string
+		assert func(TestingT, interface{}, ...interface{}) bool
+		msg    string
+	}{
+		{"IsClosed", IsClosed, "Channel should be closed, but received 7\n"},
+		{"NotClosed", NotClosed, "Channel is not closed, but checking it consumed 7\n"},
+	} {
+		ch := make(chan int)
+		sent := make(chan struct{})
+		go func() {
+			ch <- 7
+			close(sent)
+		}()
+
+		// The value is only received once the sender is blocked
+		var mockT *captureTestingT
+		var res bool
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+			mockT = new(captureTestingT)
+			if res = c.assert(mockT, ch); strings.Contains(mockT.msg, "7") {
+				break
+			}
+		}
+		mockT.checkResultAndErrMsg(t, false, res, c.msg)
+		select {
+		case <-sent:
+		case <-ch:
+			t.Errorf("%s did not receive the value sent", c.name)
+		}
+	}
+}
+
+func TestChanEmpty(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+
+	ch := make(chan int, 2)
+	True(t, ChanEmpty(mockT, ch))
+	True(t, ChanEmpty(mockT, make(chan<- int)))
+
+	ch <- 1
+	ch <- 2
+	res := ChanEmpty(mockT, ch)
+	mockT.checkResultAndErrMsg(t, false, res, "Channel should be empty, but has 2 buffered value(s)\n")
+
+	res = ChanEmpty(mockT, []int{})
+	mockT.checkResultAndErrMsg(t, false, res, "[]int is not a channel\n")
+}
@@ -0,0 +1,92 @@
+package assert
+
+import (
+	"github.com/stretchr/testify/clock"
+)
+
+// clockT is implemented by the TestingT that carry the clock used to wait
+// for time to pass, such as the ones returned by WithClock.
+type clockT interface {
+	Clock() clock.Clock
+}
+
+// fullT is a TestingT with the optional methods used by the assertions and by
+// require.
+type fullT interface {
+	TestingT
+	FailNow()
+	Helper()
+	Name() string
+}
+
+// partialT adds the optional methods of fullT to a TestingT that lacks some
+// of them.
+type partialT struct {
+	TestingT
+}
+
+func (t partialT) FailNow() {
+	if f, ok := t.TestingT.(failNower); ok {
+		f.FailNow()
+		return
+	}
+	panic("test failed and t is missing `FailNow()`")
+}
+
+func (t partialT) Helper() {
+	if h, ok := t.TestingT.(tHelper); ok {
+		h.Helper()
+	}
+}
+
+func (t partialT) Name() string {
+	if n, ok := t.TestingT.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return ""
+}
+
+// ClockT is a TestingT carrying a clock, returned by WithClock.
+type ClockT struct {
+	fullT
+	clock clock.Clock
+}
+
+// WithClock returns t with the clock c. Eventually, EventuallyWithT, Never,
+// their context aware variants and the checks of an HTTPStream use c instead
+// of the real time when they are called with it. A nil c means the real time.
+//
+// A TestingT can also carry its clock itself, with a Clock() clock.Clock
+// method.
+//
+//	fake := clock.NewFake(time.Now())
+//	ct := assert.WithClock(t, fake)
+//	assert.Eventually(ct, isReady, time.Minute, time.Second)
+func WithClock(t TestingT, c clock.Clock) *ClockT {
+	full, ok := t.(fullT)
+	if !ok {
+		full = partialT{t}
+	}
+	return &ClockT{fullT: full, clock: c}
+}
+
+// Clock returns the clock of t.
+func (t *ClockT) Clock() clock.Clock {
+	return t.clock
+}
+
+// WithClock returns assertions that use the clock c instead of the real time,
+// as WithClock does for a TestingT.
+func (a *Assertions) WithClock(c clock.Clock) *Assertions {
+	return New(WithClock(a.t, c))
+}
+
+// clockFor returns the clock carried by t, or the real clock.
+func clockFor(t TestingT) clock.Clock {
+	if ct, ok := t.(clockT); ok {
+		if c := ct.Clock(); c != nil {
+			return c
+		}
+	}
+	return clock.Real()
+}
@@ -0,0 +1,122 @@
+package assert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/clock"
+)
+
+// clockedT is a TestingT carrying its clock.
+type clockedT struct {
+	TestingT
+	clk clock.Clock
+}
+
+func (t clockedT) Clock() clock.Clock {
+	return t.clk
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Now())
+	ct := WithClock(t, fake)
+	Equal(t, fake, clockFor(ct))
+	Equal(t, t.Name(), ct.Name())
+	Equal(t, clock.Real(), clockFor(WithClock(t, nil)))
+	Equal(t, fake, clockFor(New(t).WithClock(fake).t))
+
+	Equal(t, fake, clockFor(clockedT{new(captureTestingT), fake}))
+	Equal(t, clock.Real(), clockFor(t))
+	Equal(t, clock.Real(), clockFor(nil))
+
+	// A TestingT without the methods of a testing.TB
+	mockT := new(captureTestingT)
+	ct = WithClock(mockT, fake)
+	Equal(t, fake, clockFor(New(mockT).WithClock(fake).t))
+	Equal(t, "", ct.Name())
+	False(t, Fail(ct, "failed"))
+	Contains(t, mockT.msg, "Error:      \tfailed")
+	NotContains(t, mockT.msg, "Test:")
+	PanicsWithValue(t, "test failed and t is missing `FailNow()`", ct.FailNow)
+}
+
+func TestEventuallyWithFakeClock(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Now())
+	mockT := new(captureTestingT)
+	ct := clockedT{mockT, fake}
+
+	result := make(chan bool)
+	go func() {
+		result <- Eventually(ct, func() bool { return false }, time.Hour, time.Minute)
+	}()
+
+	// Wait for the timer and the ticker of Eventually.
+	fake.WaitForTimers(2)
+	fake.Advance(time.Hour)
+
+	False(t, <-result)
+	Contains(t, mockT.msg, "Condition never satisfied")
+}
+
+func TestNeverWithFakeClock(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Now())
+	mockT := new(captureTestingT)
+	ct := clockedT{mockT, fake}
+
+	result := make(chan bool)
+	go func() {
+		result <- Never(ct, func() bool { return false }, time.Hour, time.Minute)
+	}()
+
+	fake.WaitForTimers(2)
+	fake.Advance(time.Hour)
+
+	True(t, <-result)
+}
+
+func TestEventuallyWithTWithFakeClock(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Now())
+	mockT := new(errorsCapturingT)
+	ct := clockedT{mockT, fake}
+
+	result := make(chan bool)
+	go func() {
+		result <- EventuallyWithT(ct, func(c *CollectT) {
+			Fail(c, "condition fixed failure")
+		}, time.Hour, 2*time.Hour)
+	}()
+
+	fake.WaitForTimers(2)
+	fake.Advance(time.Hour)
+
+	False(t, <-result)
+}
+
+func TestEventuallyCtxWithFakeClock(t *testing.T) {
+	t.Parallel()
+
+	fake := clock.NewFake(time.Now())
+	mockT := new(captureTestingT)
+	ct := clockedT{mockT, fake}
+
+	result := make(chan bool)
+	go func() {
+		result <- EventuallyCtx(ct, context.Background(), func(context.Context) bool { return false }, time.Hour, 2*time.Hour, nil)
+	}()
+
+	fake.WaitForTimers(2)
+	fake.Advance(time.Hour)
+
+	False(t, <-result)
+	Contains(t, mockT.msg, "Condition never satisfied after")
+	Contains(t, mockT.msg, "check(s) in 1h0m0s")
+}
@@ -0,0 +1,157 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// errorTreeIs reports whether any error in err's tree matches target. Unlike
+// errors.Is on older Go versions, it also follows Unwrap() []error.
+func errorTreeIs(err, target error) bool {
+	if err == nil {
+		return target == nil
+	}
+	for _, node := range errorTree(err, 0) {
+		if errors.Is(node.err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorTexts formats the messages of errs the way buildErrorChainString does,
+// one error per line.
+func errorTexts(errs []error) string {
+	texts := make([]string, len(errs))
+	for i, err := range errs {
+		if err != nil {
+			texts[i] = fmt.Sprintf("%q", err.Error())
+		} else {
+			texts[i] = "<nil>"
+		}
+	}
+	return strings.Join(texts, "\n\t")
+}
+
+// ErrorIsAll asserts that each of targets matches at least one of the errors
+// in err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	assert.ErrorIsAll(t, err, []error{io.EOF, fs.ErrClosed})
+func ErrorIsAll(t TestingT, err error, targets []error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	var missing []error
+	for _, target := range targets {
+		if !errorTreeIs(err, target) {
+			missing = append(missing, target)
+		}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+
+	if err == nil {
+		return Fail(t, fmt.Sprintf("Expected errors in tree but got nil:\n"+
+			"expected: %s", truncatingFormat("%s", errorTexts(targets))), msgAndArgs...)
+	}
+
+	return Fail(t, fmt.Sprintf("Target errors should all be in err tree:\n"+
+		"missing: %s\n"+
+		"in tree: %s", truncatingFormat("%s", errorTexts(missing)), truncatingFormat("%s", buildErrorChainString(err, false)),
+	), msgAndArgs...)
+}
+
+// ErrorIsAny asserts that at least one of targets matches one of the errors in
+// err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	assert.ErrorIsAny(t, err, []error{context.Canceled, context.DeadlineExceeded})
+func ErrorIsAny(t TestingT, err error, targets []error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	for _, target := range targets {
+		if errorTreeIs(err, target) {
+			return true
+		}
+	}
+
+	if err == nil {
+		return Fail(t, fmt.Sprintf("Expected one of the errors in tree but got nil:\n"+
+			"expected: %s", truncatingFormat("%s", errorTexts(targets))), msgAndArgs...)
+	}
+
+	return Fail(t, fmt.Sprintf("One of the target errors should be in err tree:\n"+
+		"expected: %s\n"+
+		"in tree: %s", truncatingFormat("%s", errorTexts(targets)), truncatingFormat("%s", buildErrorChainString(err, true)),
+	), msgAndArgs...)
+}
+
+// ErrorAsType asserts that one of the errors in err's tree has the same type
+// as sample, and returns the first such error, searching depth first. sample is
+// only used for its type and is usually a nil pointer. As with errors.As, an
+// error in the tree matches if it has an As method that accepts the type.
+//
+//	pathErr, ok := assert.ErrorAsType(t, err, (*fs.PathError)(nil))
+//	if ok {
+//		assert.Equal(t, "config.yaml", pathErr.(*fs.PathError).Path)
+//	}
+func ErrorAsType(t TestingT, err error, sample error, msgAndArgs ...interface{}) (error, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if sample == nil {
+		return nil, Fail(t, "ErrorAsType needs a non-nil sample error to know which type to look for", msgAndArgs...)
+	}
+	expectedType := reflect.TypeOf(sample)
+	if err == nil {
+		return nil, Fail(t, fmt.Sprintf("An error is expected but got nil.\n"+
+			"expected: %s", expectedType), msgAndArgs...)
+	}
+
+	target := reflect.New(expectedType)
+	for _, node := range errorTree(err, 0) {
+		if errors.As(node.err, target.Interface()) {
+			return target.Elem().Interface().(error), true
+		}
+	}
+
+	return nil, Fail(t, fmt.Sprintf("Should be in error tree:\n"+
+		"expected: %s\n"+
+		"in tree: %s", expectedType, truncatingFormat("%s", buildErrorChainString(err, true)),
+	), msgAndArgs...)
+}
+
+// ErrorMatches asserts that err is not nil and that its message matches the
+// regexp rx.
+//
+//	assert.ErrorMatches(t, err, `^open .*: no such file or directory$`)
+//	assert.ErrorMatches(t, err, regexp.MustCompile(`status \d{3}`))
+func ErrorMatches(t TestingT, err error, rx interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	r := compileRegexp(rx)
+	if err == nil {
+		return Fail(t, fmt.Sprintf("An error is expected but got nil.\n"+
+			"expected to match: %q", r), msgAndArgs...)
+	}
+
+	msg := err.Error()
+	if r.MatchString(msg) {
+		return true
+	}
+
+	return Fail(t, fmt.Sprintf("Error message not matching:\n"+
+		"expected to match: %q\n"+
+		"actual           : %s%s", r, truncatingFormat("%q", msg), closestPartialMatch(r, msg),
+	), msgAndArgs...)
+}
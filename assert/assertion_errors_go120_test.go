@@ -0,0 +1,124 @@
+//go:build go1.20
+
+package assert
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// joinedErrors mimics the error returned by errors.Join, which is not
+// available in all the Go versions testify supports. Its Unwrap() []error
+// method is rejected by go vet before Go 1.20, hence the build constraint.
+type joinedErrors []error
+
+func (e joinedErrors) Error() string {
+	texts := make([]string, len(e))
+	for i, err := range e {
+		texts[i] = err.Error()
+	}
+	return strings.Join(texts, "\n")
+}
+
+func (e joinedErrors) Unwrap() []error { return e }
+
+func TestBuildErrorChainStringTree(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("load: %w", joinedErrors{
+		fmt.Errorf("read: %w", io.EOF),
+		joinedErrors{io.ErrClosedPipe, &customError{}},
+	})
+
+	Equal(t, "\"load: read: EOF\\nio: read/write on closed pipe\\nfail\" (*fmt.wrapError)\n"+
+		"\t\"read: EOF\\nio: read/write on closed pipe\\nfail\" (assert.joinedErrors)\n"+
+		"\t\t\"read: EOF\" (*fmt.wrapError)\n"+
+		"\t\t\"EOF\" (*errors.errorString)\n"+
+		"\t\t\"io: read/write on closed pipe\\nfail\" (assert.joinedErrors)\n"+
+		"\t\t\t\"io: read/write on closed pipe\" (*errors.errorString)\n"+
+		"\t\t\t\"fail\" (*assert.customError)",
+		buildErrorChainString(err, true))
+}
+
+func TestErrorIsAll(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("wrap: %w", joinedErrors{io.EOF, fmt.Errorf("pipe: %w", io.ErrClosedPipe)})
+
+	mockT := new(captureTestingT)
+	True(t, ErrorIsAll(mockT, err, []error{io.EOF, io.ErrClosedPipe}))
+	True(t, ErrorIsAll(mockT, err, nil))
+	True(t, ErrorIsAll(mockT, nil, []error{nil}))
+
+	res := ErrorIsAll(mockT, err, []error{io.EOF, io.ErrUnexpectedEOF, io.ErrShortWrite})
+	mockT.checkResultAndErrMsg(t, false, res, "Target errors should all be in err tree:\n"+
+		"missing: \"unexpected EOF\"\n"+
+		"\t\"short write\"\n"+
+		"in tree: \"wrap: EOF\\npipe: io: read/write on closed pipe\"\n"+
+		"\t\"EOF\\npipe: io: read/write on closed pipe\"\n"+
+		"\t\t\"EOF\"\n"+
+		"\t\t\"pipe: io: read/write on closed pipe\"\n"+
+		"\t\t\"io: read/write on closed pipe\"\n")
+
+	res = ErrorIsAll(mockT, nil, []error{io.EOF})
+	mockT.checkResultAndErrMsg(t, false, res, "Expected errors in tree but got nil:\n"+
+		"expected: \"EOF\"\n")
+}
+
+func TestErrorIsAny(t *testing.T) {
+	t.Parallel()
+
+	err := joinedErrors{io.EOF, &customError{}}
+
+	mockT := new(captureTestingT)
+	True(t, ErrorIsAny(mockT, err, []error{io.ErrUnexpectedEOF, io.EOF}))
+	False(t, ErrorIsAny(mockT, err, nil))
+
+	res := ErrorIsAny(mockT, err, []error{io.ErrUnexpectedEOF, io.ErrShortWrite})
+	mockT.checkResultAndErrMsg(t, false, res, "One of the target errors should be in err tree:\n"+
+		"expected: \"unexpected EOF\"\n"+
+		"\t\"short write\"\n"+
+		"in tree: \"EOF\\nfail\" (assert.joinedErrors)\n"+
+		"\t\"EOF\" (*errors.errorString)\n"+
+		"\t\"fail\" (*assert.customError)\n")
+
+	res = ErrorIsAny(mockT, nil, []error{io.EOF})
+	mockT.checkResultAndErrMsg(t, false, res, "Expected one of the errors in tree but got nil:\n"+
+		"expected: \"EOF\"\n")
+}
+
+func TestErrorAsType(t *testing.T) {
+	t.Parallel()
+
+	custom := &customError{}
+	err := fmt.Errorf("wrap: %w", joinedErrors{io.EOF, fmt.Errorf("custom: %w", custom)})
+
+	mockT := new(captureTestingT)
+	found, ok := ErrorAsType(mockT, err, (*customError)(nil))
+	True(t, ok)
+	Same(t, custom, found)
+
+	found, ok = ErrorAsType(mockT, io.EOF, (*customError)(nil))
+	Nil(t, found)
+	mockT.checkResultAndErrMsg(t, false, ok, "Should be in error tree:\n"+
+		"expected: *assert.customError\n"+
+		"in tree: \"EOF\" (*errors.errorString)\n")
+
+	_, ok = ErrorAsType(mockT, nil, (*customError)(nil))
+	mockT.checkResultAndErrMsg(t, false, ok, "An error is expected but got nil.\n"+
+		"expected: *assert.customError\n")
+
+	_, ok = ErrorAsType(mockT, io.EOF, nil)
+	mockT.checkResultAndErrMsg(t, false, ok, "ErrorAsType needs a non-nil sample error to know which type to look for\n")
+}
+
+func TestPanicsWithErrorIsJoined(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, PanicsWithErrorIs(mockT, io.EOF, func() {
+		panic(joinedErrors{io.ErrClosedPipe, io.EOF})
+	}))
+}
@@ -0,0 +1,27 @@
+package assert
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestErrorMatches(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, ErrorMatches(mockT, errors.New("status 404: not found"), `status \d{3}`))
+	True(t, ErrorMatches(mockT, errors.New("status 404: not found"), regexp.MustCompile(`^status 4\d\d`)))
+
+	res := ErrorMatches(mockT, errors.New("status 404: not found"), `status 4\d{2}: found`)
+	mockT.checkResultAndErrMsg(t, false, res, "Error message not matching:\n"+
+		"expected to match: \"status 4\\\\d{2}: found\"\n"+
+		"actual           : \"status 404: not found\"\n"+
+		"Closest partial match: \"status 4[0-9]{2}\" matched \"status 404\" at offset 0, then failed at offset 10:\n"+
+		"status 404: not found\n"+
+		"          ^\n")
+
+	res = ErrorMatches(mockT, nil, `status`)
+	mockT.checkResultAndErrMsg(t, false, res, "An error is expected but got nil.\n"+
+		"expected to match: \"status\"\n")
+}
@@ -3,11 +3,51 @@
 package assert
 
 import (
+	context "context"
+	io "io"
+	fs "io/fs"
 	http "net/http"
 	url "net/url"
 	time "time"
 )
 
+// BytesEqualf asserts that two byte slices are equal. On failure, it shows a
+// hexdump of both around the first difference, which is easier to read than
+// the diff of Equal for binary data.
+//
+//	assert.BytesEqualf(t, []byte{0x01, 0x02}, frame, "error message %s", "formatted")
+func BytesEqualf(t TestingT, expected []byte, actual []byte, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return BytesEqual(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
+// CapturePanicf asserts that the code inside the specified PanicTestFunc
+// panics, and returns the recovered value and the stack of the panic so that
+// they can be checked further.
+//
+//	info, ok := assert.CapturePanicf(t, func(){ GoCrazy() }, "error message %s", "formatted")
+//	if ok {
+//		assert.Contains(t, info.Stack, "GoCrazy")
+//	}
+func CapturePanicf(t TestingT, f PanicTestFunc, msg string, args ...interface{}) (PanicInfo, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return CapturePanic(t, f, append([]interface{}{msg}, args...)...)
+}
+
+// ChanEmptyf asserts that there are no values in the buffer of the channel ch.
+//
+//	assert.ChanEmptyf(t, events, "error message %s", "formatted")
+func ChanEmptyf(t TestingT, ch interface{}, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ChanEmpty(t, ch, append([]interface{}{msg}, args...)...)
+}
+
 // Conditionf uses a Comparison to assert a complex condition.
 func Conditionf(t TestingT, comp Comparison, msg string, args ...interface{}) bool {
 	if h, ok := t.(tHelper); ok {
@@ -29,6 +69,28 @@ func Containsf(t TestingT, s interface{}, contains interface{}, msg string, args
 	return Contains(t, s, contains, append([]interface{}{msg}, args...)...)
 }
 
+// ContainsAnyf asserts that the specified string contains at least one of the
+// Unicode code points in chars.
+//
+//	assert.ContainsAnyf(t, "Hello World", "xyz!W", "error message %s", "formatted")
+func ContainsAnyf(t TestingT, str string, chars string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ContainsAny(t, str, chars, append([]interface{}{msg}, args...)...)
+}
+
+// DirContainsf asserts that the directory dir in fsys contains each of the
+// entries, which are names of files or directories directly inside dir.
+//
+//	assert.DirContainsf(t, os.DirFS("out"), ".", []string{"main.go", "internal"}, "error message %s", "formatted")
+func DirContainsf(t TestingT, fsys fs.FS, dir string, entries []string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirContains(t, fsys, dir, entries, append([]interface{}{msg}, args...)...)
+}
+
 // DirExistsf checks whether a directory exists in the given path. It also fails
 // if the path is a file rather a directory or there is an error checking whether it exists.
 func DirExistsf(t TestingT, path string, msg string, args ...interface{}) bool {
@@ -38,6 +100,19 @@ func DirExistsf(t TestingT, path string, msg string, args ...interface{}) bool {
 	return DirExists(t, path, append([]interface{}{msg}, args...)...)
 }
 
+// DirTreeEqualf asserts that the expected and actual file systems contain the
+// same files with the same content. It reports the files that were added,
+// removed and changed, with a diff of each changed file. Directories are only
+// compared through the files they contain, and file modes are ignored.
+//
+//	assert.DirTreeEqualf(t, os.DirFS("testdata/golden"), os.DirFS(outDir), "error message %s", "formatted")
+func DirTreeEqualf(t TestingT, expected fs.FS, actual fs.FS, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirTreeEqual(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
 // ElementsMatchf asserts that the specified listA(array, slice...) is equal to specified
 // listB(array, slice...) ignoring the order of the elements. If there are duplicate elements,
 // the number of appearances of each of them in both lists should match.
@@ -113,6 +188,29 @@ func EqualExportedValuesf(t TestingT, expected interface{}, actual interface{},
 	return EqualExportedValues(t, expected, actual, append([]interface{}{msg}, args...)...)
 }
 
+// EqualFoldf asserts that two strings are equal under simple Unicode
+// case-folding, which is a more general form of case-insensitivity.
+//
+//	assert.EqualFoldf(t, "Hello World", "hello world", "error message %s", "formatted")
+func EqualFoldf(t TestingT, expected string, actual string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualFold(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
+// EqualIgnoringWhitespacef asserts that two strings are equal when all runs of
+// whitespace are considered equivalent and leading and trailing whitespace is
+// ignored.
+//
+//	assert.EqualIgnoringWhitespacef(t, "Hello World", "  Hello\n\tWorld ", "error message %s", "formatted")
+func EqualIgnoringWhitespacef(t TestingT, expected string, actual string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualIgnoringWhitespace(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
 // EqualValuesf asserts that two objects are equal or convertible to the larger
 // type and equal.
 //
@@ -144,6 +242,22 @@ func ErrorAsf(t TestingT, err error, target interface{}, msg string, args ...int
 	return ErrorAs(t, err, target, append([]interface{}{msg}, args...)...)
 }
 
+// ErrorAsTypef asserts that one of the errors in err's tree has the same type
+// as sample, and returns the first such error, searching depth first. sample is
+// only used for its type and is usually a nil pointer. As with errors.As, an
+// error in the tree matches if it has an As method that accepts the type.
+//
+//	pathErr, ok := assert.ErrorAsTypef(t, err, (*fs.PathError)(nil), "error message %s", "formatted")
+//	if ok {
+//		assert.Equal(t, "config.yaml", pathErr.(*fs.PathError).Path)
+//	}
+func ErrorAsTypef(t TestingT, err error, sample error, msg string, args ...interface{}) (error, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorAsType(t, err, sample, append([]interface{}{msg}, args...)...)
+}
+
 // ErrorContainsf asserts that a function returned a non-nil error (i.e. an
 // error) and that the error contains the specified substring.
 //
@@ -165,6 +279,42 @@ func ErrorIsf(t TestingT, err error, target error, msg string, args ...interface
 	return ErrorIs(t, err, target, append([]interface{}{msg}, args...)...)
 }
 
+// ErrorIsAllf asserts that each of targets matches at least one of the errors
+// in err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	assert.ErrorIsAllf(t, err, []error{io.EOF, fs.ErrClosed}, "error message %s", "formatted")
+func ErrorIsAllf(t TestingT, err error, targets []error, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorIsAll(t, err, targets, append([]interface{}{msg}, args...)...)
+}
+
+// ErrorIsAnyf asserts that at least one of targets matches one of the errors in
+// err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	assert.ErrorIsAnyf(t, err, []error{context.Canceled, context.DeadlineExceeded}, "error message %s", "formatted")
+func ErrorIsAnyf(t TestingT, err error, targets []error, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorIsAny(t, err, targets, append([]interface{}{msg}, args...)...)
+}
+
+// ErrorMatchesf asserts that err is not nil and that its message matches the
+// regexp rx.
+//
+//	assert.ErrorMatchesf(t, err, `^open .*: no such file or directory$`, "error message %s", "formatted")
+//	assert.ErrorMatchesf(t, err, regexp.MustCompile(`status \d{3}`), "error message %s", "formatted")
+func ErrorMatchesf(t TestingT, err error, rx interface{}, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorMatches(t, err, rx, append([]interface{}{msg}, args...)...)
+}
+
 // Eventuallyf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick.
 //
@@ -176,6 +326,20 @@ func Eventuallyf(t TestingT, condition func() bool, waitFor time.Duration, tick
 	return Eventually(t, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
 }
 
+// EventuallyCtxf asserts that given condition will be met in waitFor time,
+// periodically checking target function each tick. It stops early if ctx is
+// done. The condition is called with a context that is done once the
+// assertion gives up. The tick grows between checks as set by backoff, or is
+// constant if backoff is nil.
+//
+//	assert.EventuallyCtxf(t, ctx, func(ctx context.Context) bool { return true }, time.Second, 10*time.Millisecond, nil, "error message %s", "formatted")
+func EventuallyCtxf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyCtx(t, ctx, condition, waitFor, tick, backoff, append([]interface{}{msg}, args...)...)
+}
+
 // EventuallyWithTf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -201,6 +365,25 @@ func EventuallyWithTf(t TestingT, condition func(collect *CollectT), waitFor tim
 	return EventuallyWithT(t, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
 }
 
+// EventuallyWithTCtxf asserts that given condition will be met in waitFor
+// time, periodically checking target function each tick, like
+// EventuallyWithT. It stops early if ctx is done. The condition is called with
+// a context that is done once the assertion gives up. If the condition is not
+// met, the collected errors of the last tick are copied to t. The tick grows
+// between checks as set by backoff, or is constant if backoff is nil.
+//
+//	assert.EventuallyWithTCtxf(t, ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx)
+//		assert.NoError(c, err)
+//		assert.Equal(c, "ready", resp)
+//	}, 10*time.Second, 100*time.Millisecond, nil, "error message %s", "formatted")
+func EventuallyWithTCtxf(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *CollectT), waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWithTCtx(t, ctx, condition, waitFor, tick, backoff, append([]interface{}{msg}, args...)...)
+}
+
 // Exactlyf asserts that two objects are equal in value and type.
 //
 //	assert.Exactlyf(t, int32(123), int64(123), "error message %s", "formatted")
@@ -237,6 +420,29 @@ func Falsef(t TestingT, value bool, msg string, args ...interface{}) bool {
 	return False(t, value, append([]interface{}{msg}, args...)...)
 }
 
+// FileContainsf asserts that the file name in fsys exists and that its content
+// contains the specified substring.
+//
+//	assert.FileContainsf(t, os.DirFS("."), "go.mod", "module example.com/app", "error message %s", "formatted")
+func FileContainsf(t TestingT, fsys fs.FS, name string, contains string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileContains(t, fsys, name, contains, append([]interface{}{msg}, args...)...)
+}
+
+// FileContentEqualsf asserts that the file name in fsys exists and that its
+// content is equal to expected. fsys can be any fs.FS, such as os.DirFS,
+// embed.FS or fstest.MapFS.
+//
+//	assert.FileContentEqualsf(t, os.DirFS("testdata"), "golden.txt", output, "error message %s", "formatted")
+func FileContentEqualsf(t TestingT, fsys fs.FS, name string, expected string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileContentEquals(t, fsys, name, expected, append([]interface{}{msg}, args...)...)
+}
+
 // FileExistsf checks whether a file exists in the given path. It also fails if
 // the path points to a directory or there is an error when trying to check the file.
 func FileExistsf(t TestingT, path string, msg string, args ...interface{}) bool {
@@ -246,6 +452,17 @@ func FileExistsf(t TestingT, path string, msg string, args ...interface{}) bool
 	return FileExists(t, path, append([]interface{}{msg}, args...)...)
 }
 
+// FileModef asserts that the file name in fsys exists and that its mode,
+// including the type bits, is equal to mode.
+//
+//	assert.FileModef(t, os.DirFS("bin"), "run.sh", 0o755, "error message %s", "formatted")
+func FileModef(t TestingT, fsys fs.FS, name string, mode fs.FileMode, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileMode(t, fsys, name, mode, append([]interface{}{msg}, args...)...)
+}
+
 // Greaterf asserts that the first element is greater than the second
 //
 //	assert.Greaterf(t, 2, 1, "error message %s", "formatted")
@@ -345,6 +562,26 @@ func HTTPSuccessf(t TestingT, handler http.HandlerFunc, method string, url strin
 	return HTTPSuccess(t, handler, method, url, values, append([]interface{}{msg}, args...)...)
 }
 
+// HasPrefixf asserts that the specified string begins with prefix.
+//
+//	assert.HasPrefixf(t, "Hello World", "Hello", "error message %s", "formatted")
+func HasPrefixf(t TestingT, str string, prefix string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return HasPrefix(t, str, prefix, append([]interface{}{msg}, args...)...)
+}
+
+// HasSuffixf asserts that the specified string ends with suffix.
+//
+//	assert.HasSuffixf(t, "Hello World", "World", "error message %s", "formatted")
+func HasSuffixf(t TestingT, str string, suffix string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return HasSuffix(t, str, suffix, append([]interface{}{msg}, args...)...)
+}
+
 // Implementsf asserts that an object is implemented by the specified interface.
 //
 //	assert.Implementsf(t, (*MyInterface)(nil), new(MyObject), "error message %s", "formatted")
@@ -397,6 +634,20 @@ func InEpsilonSlicef(t TestingT, expected interface{}, actual interface{}, epsil
 	return InEpsilonSlice(t, expected, actual, epsilon, append([]interface{}{msg}, args...)...)
 }
 
+// IsClosedf asserts that the channel ch is closed and that no values are left
+// in its buffer. It does not wait, and values left in the buffer are not
+// consumed. Telling whether ch is closed takes a receive though: a value sent
+// at that moment, such as by a sender blocked on an unbuffered channel, is
+// consumed and reported as a failure.
+//
+//	assert.IsClosedf(t, done, "error message %s", "formatted")
+func IsClosedf(t TestingT, ch interface{}, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return IsClosed(t, ch, append([]interface{}{msg}, args...)...)
+}
+
 // IsDecreasingf asserts that the collection is decreasing
 //
 //	assert.IsDecreasingf(t, []int{2, 1, 0}, "error message %s", "formatted")
@@ -511,6 +762,18 @@ func LessOrEqualf(t TestingT, e1 interface{}, e2 interface{}, msg string, args .
 	return LessOrEqual(t, e1, e2, append([]interface{}{msg}, args...)...)
 }
 
+// LinesMatchf asserts that two strings are equal line by line. The opts
+// argument controls how lines are normalized before being compared, see
+// LinesIgnoreTrailingSpace and LinesNormalizeCRLF.
+//
+//	assert.LinesMatchf(t, "a\nb\n", "a  \r\nb\r\n", assert.LinesIgnoreTrailingSpace|assert.LinesNormalizeCRLF, "error message %s", "formatted")
+func LinesMatchf(t TestingT, expected string, actual string, opts LineMatchOption, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return LinesMatch(t, expected, actual, opts, append([]interface{}{msg}, args...)...)
+}
+
 // Negativef asserts that the specified element is negative
 //
 //	assert.Negativef(t, -1, "error message %s", "formatted")
@@ -533,6 +796,31 @@ func Neverf(t TestingT, condition func() bool, waitFor time.Duration, tick time.
 	return Never(t, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
 }
 
+// NeverCtxf asserts that the given condition doesn't satisfy in waitFor time,
+// periodically checking the target function each tick. It fails if ctx is
+// done before waitFor elapses. The condition is called with a context that is
+// done once the assertion stops checking. The tick grows between checks as set
+// by backoff, or is constant if backoff is nil.
+//
+//	assert.NeverCtxf(t, ctx, func(ctx context.Context) bool { return false }, time.Second, 10*time.Millisecond, nil, "error message %s", "formatted")
+func NeverCtxf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverCtx(t, ctx, condition, waitFor, tick, backoff, append([]interface{}{msg}, args...)...)
+}
+
+// NeverReceivesf asserts that no value is received from the channel ch, and
+// that it is not closed, during the given duration.
+//
+//	assert.NeverReceivesf(t, errs, 100*time.Millisecond, "error message %s", "formatted")
+func NeverReceivesf(t TestingT, ch interface{}, duration time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverReceives(t, ch, duration, append([]interface{}{msg}, args...)...)
+}
+
 // Nilf asserts that the specified object is nil.
 //
 //	assert.Nilf(t, err, "error message %s", "formatted")
@@ -574,6 +862,37 @@ func NoFileExistsf(t TestingT, path string, msg string, args ...interface{}) boo
 	return NoFileExists(t, path, append([]interface{}{msg}, args...)...)
 }
 
+// NoGoroutineLeaksf asserts that all the goroutines started after snapshot was
+// taken stop running within waitFor, checking them each tick. Goroutines of
+// the runtime and of the testing package are ignored. If snapshot is nil,
+// every other goroutine is considered to be leaked.
+//
+//	snapshot := assert.SnapshotGoroutines()
+//	server.Start()
+//	server.Stop()
+//	assert.NoGoroutineLeaksf(t, snapshot, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func NoGoroutineLeaksf(t TestingT, snapshot *GoroutineSnapshot, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaks(t, snapshot, waitFor, tick, append([]interface{}{msg}, args...)...)
+}
+
+// NotClosedf asserts that the channel ch is not closed. A closed channel that
+// still has values in its buffer is not considered closed yet. It does not
+// wait, and values left in the buffer are not consumed. Telling whether ch is
+// closed takes a receive though: a value sent at that moment, such as by a
+// sender blocked on an unbuffered channel, is consumed and reported as a
+// failure, so NotClosedf is meant for channels without pending senders.
+//
+//	assert.NotClosedf(t, done, "error message %s", "formatted")
+func NotClosedf(t TestingT, ch interface{}, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NotClosed(t, ch, append([]interface{}{msg}, args...)...)
+}
+
 // NotContainsf asserts that the specified string, list(array, slice...) or map does NOT contain the
 // specified substring or element.
 //
@@ -757,6 +1076,45 @@ func PanicsWithErrorf(t TestingT, errString string, f PanicTestFunc, msg string,
 	return PanicsWithError(t, errString, f, append([]interface{}{msg}, args...)...)
 }
 
+// PanicsWithErrorIsf asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value is an error with target in its
+// tree, as reported by ErrorIs.
+//
+//	assert.PanicsWithErrorIsf(t, io.ErrUnexpectedEOF, func(){ GoCrazy() }, "error message %s", "formatted")
+func PanicsWithErrorIsf(t TestingT, target error, f PanicTestFunc, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithErrorIs(t, target, f, append([]interface{}{msg}, args...)...)
+}
+
+// PanicsWithMatchf asserts that the code inside the specified PanicTestFunc
+// panics, and that match returns true for the recovered panic value.
+//
+//	assert.PanicsWithMatchf(t, func(){ GoCrazy() }, func(recovered interface{}) bool {
+//		code, ok := recovered.(int)
+//		return ok && code >= 500
+//	}, "error message %s", "formatted")
+func PanicsWithMatchf(t TestingT, f PanicTestFunc, match func(recovered interface{}) bool, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithMatch(t, f, match, append([]interface{}{msg}, args...)...)
+}
+
+// PanicsWithRegexpf asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value matches the regexp rx. If the
+// value is an error, its message is matched; any other value is formatted
+// with %v.
+//
+//	assert.PanicsWithRegexpf(t, `^index out of range`, func(){ GoCrazy() }, "error message %s", "formatted")
+func PanicsWithRegexpf(t TestingT, rx interface{}, f PanicTestFunc, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithRegexp(t, rx, f, append([]interface{}{msg}, args...)...)
+}
+
 // PanicsWithValuef asserts that the code inside the specified PanicTestFunc panics, and that
 // the recovered panic value equals the expected panic value.
 //
@@ -779,6 +1137,54 @@ func Positivef(t TestingT, e interface{}, msg string, args ...interface{}) bool
 	return Positive(t, e, append([]interface{}{msg}, args...)...)
 }
 
+// ReaderContainsf asserts that the content read from r contains the specified
+// string or []byte. r is read in chunks, so that large streams are never held
+// in memory as a whole, and reading stops as soon as contains is found.
+//
+//	assert.ReaderContainsf(t, resp.Body, "<title>Home</title>", "error message %s", "formatted")
+func ReaderContainsf(t TestingT, r io.Reader, contains interface{}, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReaderContains(t, r, contains, append([]interface{}{msg}, args...)...)
+}
+
+// ReaderEqualsf asserts that the content read from actual is equal to expected,
+// which can be a string, a []byte or an io.Reader. Both are read in chunks, so
+// that large streams are never held in memory as a whole. On failure, it shows
+// a hexdump of both around the first difference. Readers are not closed.
+//
+//	assert.ReaderEqualsf(t, golden, resp.Body, "error message %s", "formatted")
+func ReaderEqualsf(t TestingT, expected interface{}, actual io.Reader, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReaderEquals(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
+// Receivesf asserts that a value is received from the channel ch within
+// timeout. It returns the received value, and whether the assertion was
+// successful.
+//
+//	assert.Receivesf(t, results, time.Second, "error message %s", "formatted")
+func Receivesf(t TestingT, ch interface{}, timeout time.Duration, msg string, args ...interface{}) (interface{}, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return Receives(t, ch, timeout, append([]interface{}{msg}, args...)...)
+}
+
+// ReceivesValuef asserts that a value is received from the channel ch within
+// timeout, and that it is equal to expected.
+//
+//	assert.ReceivesValuef(t, results, "done", time.Second, "error message %s", "formatted")
+func ReceivesValuef(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReceivesValue(t, ch, expected, timeout, append([]interface{}{msg}, args...)...)
+}
+
 // Regexpf asserts that a specified regexp matches a string.
 //
 //	assert.Regexpf(t, regexp.MustCompile("start"), "it's starting", "error message %s", "formatted")
@@ -790,6 +1196,28 @@ func Regexpf(t TestingT, rx interface{}, str interface{}, msg string, args ...in
 	return Regexp(t, rx, str, append([]interface{}{msg}, args...)...)
 }
 
+// RegexpGroupsf asserts that a specified regexp matches a string, and that the
+// named capture groups of the first match have the expected values.
+//
+//	assert.RegexpGroupsf(t, `(?P<key>\w+)=(?P<value>\w+)`, "level=debug", map[string]string{"key": "level", "value": "debug"}, "error message %s", "formatted")
+func RegexpGroupsf(t TestingT, rx interface{}, str interface{}, expectedGroups map[string]string, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return RegexpGroups(t, rx, str, expectedGroups, append([]interface{}{msg}, args...)...)
+}
+
+// RegexpMatchCountf asserts that a specified regexp matches a string exactly
+// count times, without overlapping matches.
+//
+//	assert.RegexpMatchCountf(t, "ERROR", logOutput, 2, "error message %s", "formatted")
+func RegexpMatchCountf(t TestingT, rx interface{}, str interface{}, count int, msg string, args ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return RegexpMatchCount(t, rx, str, count, append([]interface{}{msg}, args...)...)
+}
+
 // Samef asserts that two pointers reference the same object.
 //
 //	assert.Samef(t, ptr1, ptr2, "error message %s", "formatted")
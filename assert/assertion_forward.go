@@ -3,11 +3,88 @@
 package assert
 
 import (
+	context "context"
+	io "io"
+	fs "io/fs"
 	http "net/http"
 	url "net/url"
 	time "time"
 )
 
+// BytesEqual asserts that two byte slices are equal. On failure, it shows a
+// hexdump of both around the first difference, which is easier to read than
+// the diff of Equal for binary data.
+//
+//	a.BytesEqual([]byte{0x01, 0x02}, frame)
+func (a *Assertions) BytesEqual(expected []byte, actual []byte, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return BytesEqual(a.t, expected, actual, msgAndArgs...)
+}
+
+// BytesEqualf asserts that two byte slices are equal. On failure, it shows a
+// hexdump of both around the first difference, which is easier to read than
+// the diff of Equal for binary data.
+//
+//	a.BytesEqualf([]byte{0x01, 0x02}, frame, "error message %s", "formatted")
+func (a *Assertions) BytesEqualf(expected []byte, actual []byte, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return BytesEqualf(a.t, expected, actual, msg, args...)
+}
+
+// CapturePanic asserts that the code inside the specified PanicTestFunc
+// panics, and returns the recovered value and the stack of the panic so that
+// they can be checked further.
+//
+//	info, ok := a.CapturePanic(func(){ GoCrazy() })
+//	if ok {
+//		assert.Contains(t, info.Stack, "GoCrazy")
+//	}
+func (a *Assertions) CapturePanic(f PanicTestFunc, msgAndArgs ...interface{}) (PanicInfo, bool) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return CapturePanic(a.t, f, msgAndArgs...)
+}
+
+// CapturePanicf asserts that the code inside the specified PanicTestFunc
+// panics, and returns the recovered value and the stack of the panic so that
+// they can be checked further.
+//
+//	info, ok := a.CapturePanicf(func(){ GoCrazy() }, "error message %s", "formatted")
+//	if ok {
+//		assert.Contains(t, info.Stack, "GoCrazy")
+//	}
+func (a *Assertions) CapturePanicf(f PanicTestFunc, msg string, args ...interface{}) (PanicInfo, bool) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return CapturePanicf(a.t, f, msg, args...)
+}
+
+// ChanEmpty asserts that there are no values in the buffer of the channel ch.
+//
+//	a.ChanEmpty(events)
+func (a *Assertions) ChanEmpty(ch interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ChanEmpty(a.t, ch, msgAndArgs...)
+}
+
+// ChanEmptyf asserts that there are no values in the buffer of the channel ch.
+//
+//	a.ChanEmptyf(events, "error message %s", "formatted")
+func (a *Assertions) ChanEmptyf(ch interface{}, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ChanEmptyf(a.t, ch, msg, args...)
+}
+
 // Condition uses a Comparison to assert a complex condition.
 func (a *Assertions) Condition(comp Comparison, msgAndArgs ...interface{}) bool {
 	if h, ok := a.t.(tHelper); ok {
@@ -37,6 +114,28 @@ func (a *Assertions) Contains(s interface{}, contains interface{}, msgAndArgs ..
 	return Contains(a.t, s, contains, msgAndArgs...)
 }
 
+// ContainsAny asserts that the specified string contains at least one of the
+// Unicode code points in chars.
+//
+//	a.ContainsAny("Hello World", "xyz!W")
+func (a *Assertions) ContainsAny(str string, chars string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ContainsAny(a.t, str, chars, msgAndArgs...)
+}
+
+// ContainsAnyf asserts that the specified string contains at least one of the
+// Unicode code points in chars.
+//
+//	a.ContainsAnyf("Hello World", "xyz!W", "error message %s", "formatted")
+func (a *Assertions) ContainsAnyf(str string, chars string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ContainsAnyf(a.t, str, chars, msg, args...)
+}
+
 // Containsf asserts that the specified string, list(array, slice...) or map contains the
 // specified substring or element.
 //
@@ -50,6 +149,28 @@ func (a *Assertions) Containsf(s interface{}, contains interface{}, msg string,
 	return Containsf(a.t, s, contains, msg, args...)
 }
 
+// DirContains asserts that the directory dir in fsys contains each of the
+// entries, which are names of files or directories directly inside dir.
+//
+//	a.DirContains(os.DirFS("out"), ".", []string{"main.go", "internal"})
+func (a *Assertions) DirContains(fsys fs.FS, dir string, entries []string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirContains(a.t, fsys, dir, entries, msgAndArgs...)
+}
+
+// DirContainsf asserts that the directory dir in fsys contains each of the
+// entries, which are names of files or directories directly inside dir.
+//
+//	a.DirContainsf(os.DirFS("out"), ".", []string{"main.go", "internal"}, "error message %s", "formatted")
+func (a *Assertions) DirContainsf(fsys fs.FS, dir string, entries []string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirContainsf(a.t, fsys, dir, entries, msg, args...)
+}
+
 // DirExists checks whether a directory exists in the given path. It also fails
 // if the path is a file rather a directory or there is an error checking whether it exists.
 func (a *Assertions) DirExists(path string, msgAndArgs ...interface{}) bool {
@@ -68,6 +189,32 @@ func (a *Assertions) DirExistsf(path string, msg string, args ...interface{}) bo
 	return DirExistsf(a.t, path, msg, args...)
 }
 
+// DirTreeEqual asserts that the expected and actual file systems contain the
+// same files with the same content. It reports the files that were added,
+// removed and changed, with a diff of each changed file. Directories are only
+// compared through the files they contain, and file modes are ignored.
+//
+//	a.DirTreeEqual(os.DirFS("testdata/golden"), os.DirFS(outDir))
+func (a *Assertions) DirTreeEqual(expected fs.FS, actual fs.FS, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirTreeEqual(a.t, expected, actual, msgAndArgs...)
+}
+
+// DirTreeEqualf asserts that the expected and actual file systems contain the
+// same files with the same content. It reports the files that were added,
+// removed and changed, with a diff of each changed file. Directories are only
+// compared through the files they contain, and file modes are ignored.
+//
+//	a.DirTreeEqualf(os.DirFS("testdata/golden"), os.DirFS(outDir), "error message %s", "formatted")
+func (a *Assertions) DirTreeEqualf(expected fs.FS, actual fs.FS, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirTreeEqualf(a.t, expected, actual, msg, args...)
+}
+
 // ElementsMatch asserts that the specified listA(array, slice...) is equal to specified
 // listB(array, slice...) ignoring the order of the elements. If there are duplicate elements,
 // the number of appearances of each of them in both lists should match.
@@ -204,6 +351,52 @@ func (a *Assertions) EqualExportedValuesf(expected interface{}, actual interface
 	return EqualExportedValuesf(a.t, expected, actual, msg, args...)
 }
 
+// EqualFold asserts that two strings are equal under simple Unicode
+// case-folding, which is a more general form of case-insensitivity.
+//
+//	a.EqualFold("Hello World", "hello world")
+func (a *Assertions) EqualFold(expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualFold(a.t, expected, actual, msgAndArgs...)
+}
+
+// EqualFoldf asserts that two strings are equal under simple Unicode
+// case-folding, which is a more general form of case-insensitivity.
+//
+//	a.EqualFoldf("Hello World", "hello world", "error message %s", "formatted")
+func (a *Assertions) EqualFoldf(expected string, actual string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualFoldf(a.t, expected, actual, msg, args...)
+}
+
+// EqualIgnoringWhitespace asserts that two strings are equal when all runs of
+// whitespace are considered equivalent and leading and trailing whitespace is
+// ignored.
+//
+//	a.EqualIgnoringWhitespace("Hello World", "  Hello\n\tWorld ")
+func (a *Assertions) EqualIgnoringWhitespace(expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualIgnoringWhitespace(a.t, expected, actual, msgAndArgs...)
+}
+
+// EqualIgnoringWhitespacef asserts that two strings are equal when all runs of
+// whitespace are considered equivalent and leading and trailing whitespace is
+// ignored.
+//
+//	a.EqualIgnoringWhitespacef("Hello World", "  Hello\n\tWorld ", "error message %s", "formatted")
+func (a *Assertions) EqualIgnoringWhitespacef(expected string, actual string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EqualIgnoringWhitespacef(a.t, expected, actual, msg, args...)
+}
+
 // EqualValues asserts that two objects are equal or convertible to the larger
 // type and equal.
 //
@@ -260,6 +453,38 @@ func (a *Assertions) ErrorAs(err error, target interface{}, msgAndArgs ...interf
 	return ErrorAs(a.t, err, target, msgAndArgs...)
 }
 
+// ErrorAsType asserts that one of the errors in err's tree has the same type
+// as sample, and returns the first such error, searching depth first. sample is
+// only used for its type and is usually a nil pointer. As with errors.As, an
+// error in the tree matches if it has an As method that accepts the type.
+//
+//	pathErr, ok := a.ErrorAsType(err, (*fs.PathError)(nil))
+//	if ok {
+//		assert.Equal(t, "config.yaml", pathErr.(*fs.PathError).Path)
+//	}
+func (a *Assertions) ErrorAsType(err error, sample error, msgAndArgs ...interface{}) (error, bool) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorAsType(a.t, err, sample, msgAndArgs...)
+}
+
+// ErrorAsTypef asserts that one of the errors in err's tree has the same type
+// as sample, and returns the first such error, searching depth first. sample is
+// only used for its type and is usually a nil pointer. As with errors.As, an
+// error in the tree matches if it has an As method that accepts the type.
+//
+//	pathErr, ok := a.ErrorAsTypef(err, (*fs.PathError)(nil), "error message %s", "formatted")
+//	if ok {
+//		assert.Equal(t, "config.yaml", pathErr.(*fs.PathError).Path)
+//	}
+func (a *Assertions) ErrorAsTypef(err error, sample error, msg string, args ...interface{}) (error, bool) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorAsTypef(a.t, err, sample, msg, args...)
+}
+
 // ErrorAsf asserts that at least one of the errors in err's chain matches target, and if so, sets target to that error value.
 // This is a wrapper for errors.As.
 func (a *Assertions) ErrorAsf(err error, target interface{}, msg string, args ...interface{}) bool {
@@ -302,6 +527,54 @@ func (a *Assertions) ErrorIs(err error, target error, msgAndArgs ...interface{})
 	return ErrorIs(a.t, err, target, msgAndArgs...)
 }
 
+// ErrorIsAll asserts that each of targets matches at least one of the errors
+// in err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	a.ErrorIsAll(err, []error{io.EOF, fs.ErrClosed})
+func (a *Assertions) ErrorIsAll(err error, targets []error, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorIsAll(a.t, err, targets, msgAndArgs...)
+}
+
+// ErrorIsAllf asserts that each of targets matches at least one of the errors
+// in err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	a.ErrorIsAllf(err, []error{io.EOF, fs.ErrClosed}, "error message %s", "formatted")
+func (a *Assertions) ErrorIsAllf(err error, targets []error, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorIsAllf(a.t, err, targets, msg, args...)
+}
+
+// ErrorIsAny asserts that at least one of targets matches one of the errors in
+// err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	a.ErrorIsAny(err, []error{context.Canceled, context.DeadlineExceeded})
+func (a *Assertions) ErrorIsAny(err error, targets []error, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorIsAny(a.t, err, targets, msgAndArgs...)
+}
+
+// ErrorIsAnyf asserts that at least one of targets matches one of the errors in
+// err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	a.ErrorIsAnyf(err, []error{context.Canceled, context.DeadlineExceeded}, "error message %s", "formatted")
+func (a *Assertions) ErrorIsAnyf(err error, targets []error, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorIsAnyf(a.t, err, targets, msg, args...)
+}
+
 // ErrorIsf asserts that at least one of the errors in err's chain matches target.
 // This is a wrapper for errors.Is.
 func (a *Assertions) ErrorIsf(err error, target error, msg string, args ...interface{}) bool {
@@ -311,6 +584,30 @@ func (a *Assertions) ErrorIsf(err error, target error, msg string, args ...inter
 	return ErrorIsf(a.t, err, target, msg, args...)
 }
 
+// ErrorMatches asserts that err is not nil and that its message matches the
+// regexp rx.
+//
+//	a.ErrorMatches(err, `^open .*: no such file or directory$`)
+//	a.ErrorMatches(err, regexp.MustCompile(`status \d{3}`))
+func (a *Assertions) ErrorMatches(err error, rx interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorMatches(a.t, err, rx, msgAndArgs...)
+}
+
+// ErrorMatchesf asserts that err is not nil and that its message matches the
+// regexp rx.
+//
+//	a.ErrorMatchesf(err, `^open .*: no such file or directory$`, "error message %s", "formatted")
+//	a.ErrorMatchesf(err, regexp.MustCompile(`status \d{3}`), "error message %s", "formatted")
+func (a *Assertions) ErrorMatchesf(err error, rx interface{}, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ErrorMatchesf(a.t, err, rx, msg, args...)
+}
+
 // Errorf asserts that a function returned a non-nil error (ie. an error).
 //
 //	actualObj, err := SomeFunction()
@@ -333,6 +630,34 @@ func (a *Assertions) Eventually(condition func() bool, waitFor time.Duration, ti
 	return Eventually(a.t, condition, waitFor, tick, msgAndArgs...)
 }
 
+// EventuallyCtx asserts that given condition will be met in waitFor time,
+// periodically checking target function each tick. It stops early if ctx is
+// done. The condition is called with a context that is done once the
+// assertion gives up. The tick grows between checks as set by backoff, or is
+// constant if backoff is nil.
+//
+//	a.EventuallyCtx(ctx, func(ctx context.Context) bool { return true }, time.Second, 10*time.Millisecond, nil)
+func (a *Assertions) EventuallyCtx(ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyCtx(a.t, ctx, condition, waitFor, tick, backoff, msgAndArgs...)
+}
+
+// EventuallyCtxf asserts that given condition will be met in waitFor time,
+// periodically checking target function each tick. It stops early if ctx is
+// done. The condition is called with a context that is done once the
+// assertion gives up. The tick grows between checks as set by backoff, or is
+// constant if backoff is nil.
+//
+//	a.EventuallyCtxf(ctx, func(ctx context.Context) bool { return true }, time.Second, 10*time.Millisecond, nil, "error message %s", "formatted")
+func (a *Assertions) EventuallyCtxf(ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyCtxf(a.t, ctx, condition, waitFor, tick, backoff, msg, args...)
+}
+
 // EventuallyWithT asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -358,6 +683,44 @@ func (a *Assertions) EventuallyWithT(condition func(collect *CollectT), waitFor
 	return EventuallyWithT(a.t, condition, waitFor, tick, msgAndArgs...)
 }
 
+// EventuallyWithTCtx asserts that given condition will be met in waitFor
+// time, periodically checking target function each tick, like
+// EventuallyWithT. It stops early if ctx is done. The condition is called with
+// a context that is done once the assertion gives up. If the condition is not
+// met, the collected errors of the last tick are copied to t. The tick grows
+// between checks as set by backoff, or is constant if backoff is nil.
+//
+//	a.EventuallyWithTCtx(ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx)
+//		assert.NoError(c, err)
+//		assert.Equal(c, "ready", resp)
+//	}, 10*time.Second, 100*time.Millisecond, nil)
+func (a *Assertions) EventuallyWithTCtx(ctx context.Context, condition func(ctx context.Context, collect *CollectT), waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWithTCtx(a.t, ctx, condition, waitFor, tick, backoff, msgAndArgs...)
+}
+
+// EventuallyWithTCtxf asserts that given condition will be met in waitFor
+// time, periodically checking target function each tick, like
+// EventuallyWithT. It stops early if ctx is done. The condition is called with
+// a context that is done once the assertion gives up. If the condition is not
+// met, the collected errors of the last tick are copied to t. The tick grows
+// between checks as set by backoff, or is constant if backoff is nil.
+//
+//	a.EventuallyWithTCtxf(ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx)
+//		assert.NoError(c, err)
+//		assert.Equal(c, "ready", resp)
+//	}, 10*time.Second, 100*time.Millisecond, nil, "error message %s", "formatted")
+func (a *Assertions) EventuallyWithTCtxf(ctx context.Context, condition func(ctx context.Context, collect *CollectT), waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWithTCtxf(a.t, ctx, condition, waitFor, tick, backoff, msg, args...)
+}
+
 // EventuallyWithTf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -466,6 +829,52 @@ func (a *Assertions) Falsef(value bool, msg string, args ...interface{}) bool {
 	return Falsef(a.t, value, msg, args...)
 }
 
+// FileContains asserts that the file name in fsys exists and that its content
+// contains the specified substring.
+//
+//	a.FileContains(os.DirFS("."), "go.mod", "module example.com/app")
+func (a *Assertions) FileContains(fsys fs.FS, name string, contains string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileContains(a.t, fsys, name, contains, msgAndArgs...)
+}
+
+// FileContainsf asserts that the file name in fsys exists and that its content
+// contains the specified substring.
+//
+//	a.FileContainsf(os.DirFS("."), "go.mod", "module example.com/app", "error message %s", "formatted")
+func (a *Assertions) FileContainsf(fsys fs.FS, name string, contains string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileContainsf(a.t, fsys, name, contains, msg, args...)
+}
+
+// FileContentEquals asserts that the file name in fsys exists and that its
+// content is equal to expected. fsys can be any fs.FS, such as os.DirFS,
+// embed.FS or fstest.MapFS.
+//
+//	a.FileContentEquals(os.DirFS("testdata"), "golden.txt", output)
+func (a *Assertions) FileContentEquals(fsys fs.FS, name string, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileContentEquals(a.t, fsys, name, expected, msgAndArgs...)
+}
+
+// FileContentEqualsf asserts that the file name in fsys exists and that its
+// content is equal to expected. fsys can be any fs.FS, such as os.DirFS,
+// embed.FS or fstest.MapFS.
+//
+//	a.FileContentEqualsf(os.DirFS("testdata"), "golden.txt", output, "error message %s", "formatted")
+func (a *Assertions) FileContentEqualsf(fsys fs.FS, name string, expected string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileContentEqualsf(a.t, fsys, name, expected, msg, args...)
+}
+
 // FileExists checks whether a file exists in the given path. It also fails if
 // the path points to a directory or there is an error when trying to check the file.
 func (a *Assertions) FileExists(path string, msgAndArgs ...interface{}) bool {
@@ -484,6 +893,28 @@ func (a *Assertions) FileExistsf(path string, msg string, args ...interface{}) b
 	return FileExistsf(a.t, path, msg, args...)
 }
 
+// FileMode asserts that the file name in fsys exists and that its mode,
+// including the type bits, is equal to mode.
+//
+//	a.FileMode(os.DirFS("bin"), "run.sh", 0o755)
+func (a *Assertions) FileMode(fsys fs.FS, name string, mode fs.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileMode(a.t, fsys, name, mode, msgAndArgs...)
+}
+
+// FileModef asserts that the file name in fsys exists and that its mode,
+// including the type bits, is equal to mode.
+//
+//	a.FileModef(os.DirFS("bin"), "run.sh", 0o755, "error message %s", "formatted")
+func (a *Assertions) FileModef(fsys fs.FS, name string, mode fs.FileMode, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileModef(a.t, fsys, name, mode, msg, args...)
+}
+
 // Greater asserts that the first element is greater than the second
 //
 //	a.Greater(2, 1)
@@ -682,6 +1113,46 @@ func (a *Assertions) HTTPSuccessf(handler http.HandlerFunc, method string, url s
 	return HTTPSuccessf(a.t, handler, method, url, values, msg, args...)
 }
 
+// HasPrefix asserts that the specified string begins with prefix.
+//
+//	a.HasPrefix("Hello World", "Hello")
+func (a *Assertions) HasPrefix(str string, prefix string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HasPrefix(a.t, str, prefix, msgAndArgs...)
+}
+
+// HasPrefixf asserts that the specified string begins with prefix.
+//
+//	a.HasPrefixf("Hello World", "Hello", "error message %s", "formatted")
+func (a *Assertions) HasPrefixf(str string, prefix string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HasPrefixf(a.t, str, prefix, msg, args...)
+}
+
+// HasSuffix asserts that the specified string ends with suffix.
+//
+//	a.HasSuffix("Hello World", "World")
+func (a *Assertions) HasSuffix(str string, suffix string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HasSuffix(a.t, str, suffix, msgAndArgs...)
+}
+
+// HasSuffixf asserts that the specified string ends with suffix.
+//
+//	a.HasSuffixf("Hello World", "World", "error message %s", "formatted")
+func (a *Assertions) HasSuffixf(str string, suffix string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HasSuffixf(a.t, str, suffix, msg, args...)
+}
+
 // Implements asserts that an object is implemented by the specified interface.
 //
 //	a.Implements((*MyInterface)(nil), new(MyObject))
@@ -786,6 +1257,34 @@ func (a *Assertions) InEpsilonf(expected interface{}, actual interface{}, epsilo
 	return InEpsilonf(a.t, expected, actual, epsilon, msg, args...)
 }
 
+// IsClosed asserts that the channel ch is closed and that no values are left
+// in its buffer. It does not wait, and values left in the buffer are not
+// consumed. Telling whether ch is closed takes a receive though: a value sent
+// at that moment, such as by a sender blocked on an unbuffered channel, is
+// consumed and reported as a failure.
+//
+//	a.IsClosed(done)
+func (a *Assertions) IsClosed(ch interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return IsClosed(a.t, ch, msgAndArgs...)
+}
+
+// IsClosedf asserts that the channel ch is closed and that no values are left
+// in its buffer. It does not wait, and values left in the buffer are not
+// consumed. Telling whether ch is closed takes a receive though: a value sent
+// at that moment, such as by a sender blocked on an unbuffered channel, is
+// consumed and reported as a failure.
+//
+//	a.IsClosedf(done, "error message %s", "formatted")
+func (a *Assertions) IsClosedf(ch interface{}, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return IsClosedf(a.t, ch, msg, args...)
+}
+
 // IsDecreasing asserts that the collection is decreasing
 //
 //	a.IsDecreasing([]int{2, 1, 0})
@@ -1014,6 +1513,30 @@ func (a *Assertions) Lessf(e1 interface{}, e2 interface{}, msg string, args ...i
 	return Lessf(a.t, e1, e2, msg, args...)
 }
 
+// LinesMatch asserts that two strings are equal line by line. The opts
+// argument controls how lines are normalized before being compared, see
+// LinesIgnoreTrailingSpace and LinesNormalizeCRLF.
+//
+//	a.LinesMatch("a\nb\n", "a  \r\nb\r\n", assert.LinesIgnoreTrailingSpace|assert.LinesNormalizeCRLF)
+func (a *Assertions) LinesMatch(expected string, actual string, opts LineMatchOption, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return LinesMatch(a.t, expected, actual, opts, msgAndArgs...)
+}
+
+// LinesMatchf asserts that two strings are equal line by line. The opts
+// argument controls how lines are normalized before being compared, see
+// LinesIgnoreTrailingSpace and LinesNormalizeCRLF.
+//
+//	a.LinesMatchf("a\nb\n", "a  \r\nb\r\n", assert.LinesIgnoreTrailingSpace|assert.LinesNormalizeCRLF, "error message %s", "formatted")
+func (a *Assertions) LinesMatchf(expected string, actual string, opts LineMatchOption, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return LinesMatchf(a.t, expected, actual, opts, msg, args...)
+}
+
 // Negative asserts that the specified element is negative
 //
 //	a.Negative(-1)
@@ -1047,6 +1570,56 @@ func (a *Assertions) Never(condition func() bool, waitFor time.Duration, tick ti
 	return Never(a.t, condition, waitFor, tick, msgAndArgs...)
 }
 
+// NeverCtx asserts that the given condition doesn't satisfy in waitFor time,
+// periodically checking the target function each tick. It fails if ctx is
+// done before waitFor elapses. The condition is called with a context that is
+// done once the assertion stops checking. The tick grows between checks as set
+// by backoff, or is constant if backoff is nil.
+//
+//	a.NeverCtx(ctx, func(ctx context.Context) bool { return false }, time.Second, 10*time.Millisecond, nil)
+func (a *Assertions) NeverCtx(ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverCtx(a.t, ctx, condition, waitFor, tick, backoff, msgAndArgs...)
+}
+
+// NeverCtxf asserts that the given condition doesn't satisfy in waitFor time,
+// periodically checking the target function each tick. It fails if ctx is
+// done before waitFor elapses. The condition is called with a context that is
+// done once the assertion stops checking. The tick grows between checks as set
+// by backoff, or is constant if backoff is nil.
+//
+//	a.NeverCtxf(ctx, func(ctx context.Context) bool { return false }, time.Second, 10*time.Millisecond, nil, "error message %s", "formatted")
+func (a *Assertions) NeverCtxf(ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverCtxf(a.t, ctx, condition, waitFor, tick, backoff, msg, args...)
+}
+
+// NeverReceives asserts that no value is received from the channel ch, and
+// that it is not closed, during the given duration.
+//
+//	a.NeverReceives(errs, 100*time.Millisecond)
+func (a *Assertions) NeverReceives(ch interface{}, duration time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverReceives(a.t, ch, duration, msgAndArgs...)
+}
+
+// NeverReceivesf asserts that no value is received from the channel ch, and
+// that it is not closed, during the given duration.
+//
+//	a.NeverReceivesf(errs, 100*time.Millisecond, "error message %s", "formatted")
+func (a *Assertions) NeverReceivesf(ch interface{}, duration time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverReceivesf(a.t, ch, duration, msg, args...)
+}
+
 // Neverf asserts that the given condition doesn't satisfy in waitFor time,
 // periodically checking the target function each tick.
 //
@@ -1140,6 +1713,68 @@ func (a *Assertions) NoFileExistsf(path string, msg string, args ...interface{})
 	return NoFileExistsf(a.t, path, msg, args...)
 }
 
+// NoGoroutineLeaks asserts that all the goroutines started after snapshot was
+// taken stop running within waitFor, checking them each tick. Goroutines of
+// the runtime and of the testing package are ignored. If snapshot is nil,
+// every other goroutine is considered to be leaked.
+//
+//	snapshot := assert.SnapshotGoroutines()
+//	server.Start()
+//	server.Stop()
+//	a.NoGoroutineLeaks(snapshot, time.Second, 10*time.Millisecond)
+func (a *Assertions) NoGoroutineLeaks(snapshot *GoroutineSnapshot, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaks(a.t, snapshot, waitFor, tick, msgAndArgs...)
+}
+
+// NoGoroutineLeaksf asserts that all the goroutines started after snapshot was
+// taken stop running within waitFor, checking them each tick. Goroutines of
+// the runtime and of the testing package are ignored. If snapshot is nil,
+// every other goroutine is considered to be leaked.
+//
+//	snapshot := assert.SnapshotGoroutines()
+//	server.Start()
+//	server.Stop()
+//	a.NoGoroutineLeaksf(snapshot, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func (a *Assertions) NoGoroutineLeaksf(snapshot *GoroutineSnapshot, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaksf(a.t, snapshot, waitFor, tick, msg, args...)
+}
+
+// NotClosed asserts that the channel ch is not closed. A closed channel that
+// still has values in its buffer is not considered closed yet. It does not
+// wait, and values left in the buffer are not consumed. Telling whether ch is
+// closed takes a receive though: a value sent at that moment, such as by a
+// sender blocked on an unbuffered channel, is consumed and reported as a
+// failure, so NotClosed is meant for channels without pending senders.
+//
+//	a.NotClosed(done)
+func (a *Assertions) NotClosed(ch interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NotClosed(a.t, ch, msgAndArgs...)
+}
+
+// NotClosedf asserts that the channel ch is not closed. A closed channel that
+// still has values in its buffer is not considered closed yet. It does not
+// wait, and values left in the buffer are not consumed. Telling whether ch is
+// closed takes a receive though: a value sent at that moment, such as by a
+// sender blocked on an unbuffered channel, is consumed and reported as a
+// failure, so NotClosedf is meant for channels without pending senders.
+//
+//	a.NotClosedf(done, "error message %s", "formatted")
+func (a *Assertions) NotClosedf(ch interface{}, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NotClosedf(a.t, ch, msg, args...)
+}
+
 // NotContains asserts that the specified string, list(array, slice...) or map does NOT contain the
 // specified substring or element.
 //
@@ -1484,6 +2119,30 @@ func (a *Assertions) PanicsWithError(errString string, f PanicTestFunc, msgAndAr
 	return PanicsWithError(a.t, errString, f, msgAndArgs...)
 }
 
+// PanicsWithErrorIs asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value is an error with target in its
+// tree, as reported by ErrorIs.
+//
+//	a.PanicsWithErrorIs(io.ErrUnexpectedEOF, func(){ GoCrazy() })
+func (a *Assertions) PanicsWithErrorIs(target error, f PanicTestFunc, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithErrorIs(a.t, target, f, msgAndArgs...)
+}
+
+// PanicsWithErrorIsf asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value is an error with target in its
+// tree, as reported by ErrorIs.
+//
+//	a.PanicsWithErrorIsf(io.ErrUnexpectedEOF, func(){ GoCrazy() }, "error message %s", "formatted")
+func (a *Assertions) PanicsWithErrorIsf(target error, f PanicTestFunc, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithErrorIsf(a.t, target, f, msg, args...)
+}
+
 // PanicsWithErrorf asserts that the code inside the specified PanicTestFunc
 // panics, and that the recovered panic value is an error that satisfies the
 // EqualError comparison.
@@ -1496,6 +2155,60 @@ func (a *Assertions) PanicsWithErrorf(errString string, f PanicTestFunc, msg str
 	return PanicsWithErrorf(a.t, errString, f, msg, args...)
 }
 
+// PanicsWithMatch asserts that the code inside the specified PanicTestFunc
+// panics, and that match returns true for the recovered panic value.
+//
+//	a.PanicsWithMatch(func(){ GoCrazy() }, func(recovered interface{}) bool {
+//		code, ok := recovered.(int)
+//		return ok && code >= 500
+//	})
+func (a *Assertions) PanicsWithMatch(f PanicTestFunc, match func(recovered interface{}) bool, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithMatch(a.t, f, match, msgAndArgs...)
+}
+
+// PanicsWithMatchf asserts that the code inside the specified PanicTestFunc
+// panics, and that match returns true for the recovered panic value.
+//
+//	a.PanicsWithMatchf(func(){ GoCrazy() }, func(recovered interface{}) bool {
+//		code, ok := recovered.(int)
+//		return ok && code >= 500
+//	}, "error message %s", "formatted")
+func (a *Assertions) PanicsWithMatchf(f PanicTestFunc, match func(recovered interface{}) bool, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithMatchf(a.t, f, match, msg, args...)
+}
+
+// PanicsWithRegexp asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value matches the regexp rx. If the
+// value is an error, its message is matched; any other value is formatted
+// with %v.
+//
+//	a.PanicsWithRegexp(`^index out of range`, func(){ GoCrazy() })
+func (a *Assertions) PanicsWithRegexp(rx interface{}, f PanicTestFunc, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithRegexp(a.t, rx, f, msgAndArgs...)
+}
+
+// PanicsWithRegexpf asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value matches the regexp rx. If the
+// value is an error, its message is matched; any other value is formatted
+// with %v.
+//
+//	a.PanicsWithRegexpf(`^index out of range`, func(){ GoCrazy() }, "error message %s", "formatted")
+func (a *Assertions) PanicsWithRegexpf(rx interface{}, f PanicTestFunc, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return PanicsWithRegexpf(a.t, rx, f, msg, args...)
+}
+
 // PanicsWithValue asserts that the code inside the specified PanicTestFunc panics, and that
 // the recovered panic value equals the expected panic value.
 //
@@ -1550,6 +2263,102 @@ func (a *Assertions) Positivef(e interface{}, msg string, args ...interface{}) b
 	return Positivef(a.t, e, msg, args...)
 }
 
+// ReaderContains asserts that the content read from r contains the specified
+// string or []byte. r is read in chunks, so that large streams are never held
+// in memory as a whole, and reading stops as soon as contains is found.
+//
+//	a.ReaderContains(resp.Body, "<title>Home</title>")
+func (a *Assertions) ReaderContains(r io.Reader, contains interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReaderContains(a.t, r, contains, msgAndArgs...)
+}
+
+// ReaderContainsf asserts that the content read from r contains the specified
+// string or []byte. r is read in chunks, so that large streams are never held
+// in memory as a whole, and reading stops as soon as contains is found.
+//
+//	a.ReaderContainsf(resp.Body, "<title>Home</title>", "error message %s", "formatted")
+func (a *Assertions) ReaderContainsf(r io.Reader, contains interface{}, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReaderContainsf(a.t, r, contains, msg, args...)
+}
+
+// ReaderEquals asserts that the content read from actual is equal to expected,
+// which can be a string, a []byte or an io.Reader. Both are read in chunks, so
+// that large streams are never held in memory as a whole. On failure, it shows
+// a hexdump of both around the first difference. Readers are not closed.
+//
+//	a.ReaderEquals(golden, resp.Body)
+func (a *Assertions) ReaderEquals(expected interface{}, actual io.Reader, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReaderEquals(a.t, expected, actual, msgAndArgs...)
+}
+
+// ReaderEqualsf asserts that the content read from actual is equal to expected,
+// which can be a string, a []byte or an io.Reader. Both are read in chunks, so
+// that large streams are never held in memory as a whole. On failure, it shows
+// a hexdump of both around the first difference. Readers are not closed.
+//
+//	a.ReaderEqualsf(golden, resp.Body, "error message %s", "formatted")
+func (a *Assertions) ReaderEqualsf(expected interface{}, actual io.Reader, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReaderEqualsf(a.t, expected, actual, msg, args...)
+}
+
+// Receives asserts that a value is received from the channel ch within
+// timeout. It returns the received value, and whether the assertion was
+// successful.
+//
+//	a.Receives(results, time.Second)
+func (a *Assertions) Receives(ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) (interface{}, bool) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Receives(a.t, ch, timeout, msgAndArgs...)
+}
+
+// ReceivesValue asserts that a value is received from the channel ch within
+// timeout, and that it is equal to expected.
+//
+//	a.ReceivesValue(results, "done", time.Second)
+func (a *Assertions) ReceivesValue(ch interface{}, expected interface{}, timeout time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReceivesValue(a.t, ch, expected, timeout, msgAndArgs...)
+}
+
+// ReceivesValuef asserts that a value is received from the channel ch within
+// timeout, and that it is equal to expected.
+//
+//	a.ReceivesValuef(results, "done", time.Second, "error message %s", "formatted")
+func (a *Assertions) ReceivesValuef(ch interface{}, expected interface{}, timeout time.Duration, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return ReceivesValuef(a.t, ch, expected, timeout, msg, args...)
+}
+
+// Receivesf asserts that a value is received from the channel ch within
+// timeout. It returns the received value, and whether the assertion was
+// successful.
+//
+//	a.Receivesf(results, time.Second, "error message %s", "formatted")
+func (a *Assertions) Receivesf(ch interface{}, timeout time.Duration, msg string, args ...interface{}) (interface{}, bool) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Receivesf(a.t, ch, timeout, msg, args...)
+}
+
 // Regexp asserts that a specified regexp matches a string.
 //
 //	a.Regexp(regexp.MustCompile("start"), "it's starting")
@@ -1561,6 +2370,50 @@ func (a *Assertions) Regexp(rx interface{}, str interface{}, msgAndArgs ...inter
 	return Regexp(a.t, rx, str, msgAndArgs...)
 }
 
+// RegexpGroups asserts that a specified regexp matches a string, and that the
+// named capture groups of the first match have the expected values.
+//
+//	a.RegexpGroups(`(?P<key>\w+)=(?P<value>\w+)`, "level=debug", map[string]string{"key": "level", "value": "debug"})
+func (a *Assertions) RegexpGroups(rx interface{}, str interface{}, expectedGroups map[string]string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return RegexpGroups(a.t, rx, str, expectedGroups, msgAndArgs...)
+}
+
+// RegexpGroupsf asserts that a specified regexp matches a string, and that the
+// named capture groups of the first match have the expected values.
+//
+//	a.RegexpGroupsf(`(?P<key>\w+)=(?P<value>\w+)`, "level=debug", map[string]string{"key": "level", "value": "debug"}, "error message %s", "formatted")
+func (a *Assertions) RegexpGroupsf(rx interface{}, str interface{}, expectedGroups map[string]string, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return RegexpGroupsf(a.t, rx, str, expectedGroups, msg, args...)
+}
+
+// RegexpMatchCount asserts that a specified regexp matches a string exactly
+// count times, without overlapping matches.
+//
+//	a.RegexpMatchCount("ERROR", logOutput, 2)
+func (a *Assertions) RegexpMatchCount(rx interface{}, str interface{}, count int, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return RegexpMatchCount(a.t, rx, str, count, msgAndArgs...)
+}
+
+// RegexpMatchCountf asserts that a specified regexp matches a string exactly
+// count times, without overlapping matches.
+//
+//	a.RegexpMatchCountf("ERROR", logOutput, 2, "error message %s", "formatted")
+func (a *Assertions) RegexpMatchCountf(rx interface{}, str interface{}, count int, msg string, args ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return RegexpMatchCountf(a.t, rx, str, count, msg, args...)
+}
+
 // Regexpf asserts that a specified regexp matches a string.
 //
 //	a.Regexpf(regexp.MustCompile("start"), "it's starting", "error message %s", "formatted")
@@ -0,0 +1,242 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/internal/difflib"
+)
+
+// readFSFile reads the file name from fsys. If it fails, the returned message
+// describes the error.
+func readFSFile(fsys fs.FS, name string) ([]byte, string) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Sprintf("unable to find file %q", name)
+		}
+		return nil, fmt.Sprintf("error when reading %q: %s", name, err)
+	}
+	return content, ""
+}
+
+// splitFileLines splits content into lines that each end with a newline, for
+// use in a diff.
+func splitFileLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+// fileDiff returns a unified diff of the expected and actual content of the
+// file name. Files that are not valid UTF-8 are reported as binary instead.
+func fileDiff(name string, expected, actual []byte) string {
+	if !utf8.Valid(expected) || !utf8.Valid(actual) {
+		return fmt.Sprintf("Binary file %s differs\n", name)
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitFileLines(expected),
+		B:        splitFileLines(actual),
+		FromFile: "expected/" + name,
+		FromDate: "",
+		ToFile:   "actual/" + name,
+		ToDate:   "",
+		Context:  1,
+	})
+	return diff
+}
+
+// FileContentEquals asserts that the file name in fsys exists and that its
+// content is equal to expected. fsys can be any fs.FS, such as os.DirFS,
+// embed.FS or fstest.MapFS.
+//
+//	assert.FileContentEquals(t, os.DirFS("testdata"), "golden.txt", output)
+func FileContentEquals(t TestingT, fsys fs.FS, name string, expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	content, errMsg := readFSFile(fsys, name)
+	if errMsg != "" {
+		return Fail(t, errMsg, msgAndArgs...)
+	}
+	if string(content) == expected {
+		return true
+	}
+
+	return Fail(t, fmt.Sprintf("File %q content not equal\n\nDiff:\n", name)+fileDiff(name, []byte(expected), content), msgAndArgs...)
+}
+
+// FileContains asserts that the file name in fsys exists and that its content
+// contains the specified substring.
+//
+//	assert.FileContains(t, os.DirFS("."), "go.mod", "module example.com/app")
+func FileContains(t TestingT, fsys fs.FS, name string, contains string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	content, errMsg := readFSFile(fsys, name)
+	if errMsg != "" {
+		return Fail(t, errMsg, msgAndArgs...)
+	}
+	if bytes.Contains(content, []byte(contains)) {
+		return true
+	}
+
+	return Fail(t, fmt.Sprintf("File %q does not contain %#v:\n%s", name, contains, truncatingFormat("%s", string(content))), msgAndArgs...)
+}
+
+// FileMode asserts that the file name in fsys exists and that its mode,
+// including the type bits, is equal to mode.
+//
+//	assert.FileMode(t, os.DirFS("bin"), "run.sh", 0o755)
+func FileMode(t TestingT, fsys fs.FS, name string, mode fs.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Fail(t, fmt.Sprintf("unable to find file %q", name), msgAndArgs...)
+		}
+		return Fail(t, fmt.Sprintf("error when running fs.Stat(%q): %s", name, err), msgAndArgs...)
+	}
+	if info.Mode() == mode {
+		return true
+	}
+
+	return Fail(t, fmt.Sprintf("File %q has unexpected mode:\n"+
+		"expected: %v\n"+
+		"actual  : %v", name, mode, info.Mode()), msgAndArgs...)
+}
+
+// DirContains asserts that the directory dir in fsys contains each of the
+// entries, which are names of files or directories directly inside dir.
+//
+//	assert.DirContains(t, os.DirFS("out"), ".", []string{"main.go", "internal"})
+func DirContains(t TestingT, fsys fs.FS, dir string, entries []string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	dirEntries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Fail(t, fmt.Sprintf("unable to find directory %q", dir), msgAndArgs...)
+		}
+		return Fail(t, fmt.Sprintf("error when running fs.ReadDir(%q): %s", dir, err), msgAndArgs...)
+	}
+
+	names := make(map[string]bool, len(dirEntries))
+	actual := make([]string, len(dirEntries))
+	for i, entry := range dirEntries {
+		names[entry.Name()] = true
+		actual[i] = entry.Name()
+	}
+
+	var missing []string
+	for _, entry := range entries {
+		if !names[entry] {
+			missing = append(missing, entry)
+		}
+	}
+	if len(missing) == 0 {
+		return true
+	}
+
+	return Fail(t, fmt.Sprintf("Directory %q does not contain %s\n"+
+		"entries: %s", dir, truncatingFormat("%q", missing), truncatingFormat("%q", actual)), msgAndArgs...)
+}
+
+// fsFiles returns the content of all the files in fsys, by path.
+func fsFiles(fsys fs.FS) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		files[path] = content
+		return nil
+	})
+	return files, err
+}
+
+// DirTreeEqual asserts that the expected and actual file systems contain the
+// same files with the same content. It reports the files that were added,
+// removed and changed, with a diff of each changed file. Directories are only
+// compared through the files they contain, and file modes are ignored.
+//
+//	assert.DirTreeEqual(t, os.DirFS("testdata/golden"), os.DirFS(outDir))
+func DirTreeEqual(t TestingT, expected, actual fs.FS, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	expectedFiles, err := fsFiles(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("error when reading expected files: %s", err), msgAndArgs...)
+	}
+	actualFiles, err := fsFiles(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("error when reading actual files: %s", err), msgAndArgs...)
+	}
+
+	var added, removed, changed []string
+	for name, content := range actualFiles {
+		expectedContent, ok := expectedFiles[name]
+		if !ok {
+			added = append(added, name)
+		} else if !bytes.Equal(expectedContent, content) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range expectedFiles {
+		if _, ok := actualFiles[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+		return true
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+
+	var msg strings.Builder
+	msg.WriteString("Directory trees are not equal:")
+	for _, group := range []struct {
+		title string
+		names []string
+	}{{"Added", added}, {"Removed", removed}, {"Changed", changed}} {
+		if len(group.names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&msg, "\n%s:", group.title)
+		for _, name := range group.names {
+			msg.WriteString("\n\t" + name)
+		}
+	}
+	if len(changed) > 0 {
+		msg.WriteString("\n\nDiff:\n")
+		for _, name := range changed {
+			msg.WriteString(fileDiff(name, expectedFiles[name], actualFiles[name]))
+		}
+	}
+
+	return Fail(t, msg.String(), msgAndArgs...)
+}
@@ -0,0 +1,125 @@
+package assert
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+var testFS = fstest.MapFS{
+	"README.md":         {Data: []byte("# Example\n")},
+	"bin/run.sh":        {Data: []byte("#!/bin/sh\necho run\n"), Mode: 0o755},
+	"pkg":               {Mode: fs.ModeDir | 0o555},
+	"pkg/main.go":       {Data: []byte("package main\n\nfunc main() {\n}\n")},
+	"pkg/internal/x.go": {Data: []byte("package internal\n")},
+}
+
+func TestFileContentEquals(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, FileContentEquals(mockT, testFS, "README.md", "# Example\n"))
+
+	res := FileContentEquals(mockT, testFS, "pkg/main.go", "package main\n\nfunc run() {\n}\n")
+	mockT.checkResultAndErrMsg(t, false, res, "File \"pkg/main.go\" content not equal\n\n"+
+		"Diff:\n"+
+		"--- expected/pkg/main.go\n"+
+		"+++ actual/pkg/main.go\n"+
+		"@@ -2,3 +2,3 @@\n"+
+		" \n"+
+		"-func run() {\n"+
+		"+func main() {\n"+
+		" }\n")
+
+	res = FileContentEquals(mockT, testFS, "missing.txt", "")
+	mockT.checkResultAndErrMsg(t, false, res, "unable to find file \"missing.txt\"\n")
+
+	res = FileContentEquals(mockT, testFS, "pkg", "")
+	False(t, res)
+	Contains(t, mockT.msg, "error when reading \"pkg\"")
+}
+
+func TestFileContains(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, FileContains(mockT, testFS, "bin/run.sh", "echo run"))
+
+	res := FileContains(mockT, testFS, "README.md", "Usage")
+	mockT.checkResultAndErrMsg(t, false, res, "File \"README.md\" does not contain \"Usage\":\n# Example\n")
+
+	res = FileContains(mockT, testFS, "missing.txt", "")
+	mockT.checkResultAndErrMsg(t, false, res, "unable to find file \"missing.txt\"\n")
+}
+
+func TestFileMode(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, FileMode(mockT, testFS, "bin/run.sh", 0o755))
+	True(t, FileMode(mockT, testFS, "pkg", fs.ModeDir|0o555))
+
+	res := FileMode(mockT, testFS, "bin/run.sh", 0o644)
+	mockT.checkResultAndErrMsg(t, false, res, "File \"bin/run.sh\" has unexpected mode:\n"+
+		"expected: -rw-r--r--\n"+
+		"actual  : -rwxr-xr-x\n")
+
+	res = FileMode(mockT, testFS, "missing.txt", 0o644)
+	mockT.checkResultAndErrMsg(t, false, res, "unable to find file \"missing.txt\"\n")
+}
+
+func TestDirContains(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, DirContains(mockT, testFS, ".", []string{"README.md", "pkg"}))
+	True(t, DirContains(mockT, testFS, "pkg", nil))
+
+	res := DirContains(mockT, testFS, "pkg", []string{"main.go", "main_test.go", "cmd"})
+	mockT.checkResultAndErrMsg(t, false, res, "Directory \"pkg\" does not contain [\"main_test.go\" \"cmd\"]\n"+
+		"entries: [\"internal\" \"main.go\"]\n")
+
+	res = DirContains(mockT, testFS, "missing", nil)
+	mockT.checkResultAndErrMsg(t, false, res, "unable to find directory \"missing\"\n")
+}
+
+func TestDirTreeEqual(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, DirTreeEqual(mockT, testFS, testFS))
+	True(t, DirTreeEqual(mockT, fstest.MapFS{}, fstest.MapFS{"empty": {Mode: fs.ModeDir}}))
+
+	actual := fstest.MapFS{
+		"README.md":   {Data: []byte("# Example\n\nMore.\n")},
+		"bin/run.sh":  {Data: []byte("#!/bin/sh\necho run\n")},
+		"pkg/main.go": {Data: []byte("package main\n\nfunc main() {\n}\n")},
+		"pkg/y.go":    {Data: []byte("package pkg\n")},
+		"logo.png":    {Data: []byte{0x89, 'P', 'N', 'G'}},
+	}
+	res := DirTreeEqual(mockT, testFS, actual)
+	mockT.checkResultAndErrMsg(t, false, res, "Directory trees are not equal:\n"+
+		"Added:\n"+
+		"\tlogo.png\n"+
+		"\tpkg/y.go\n"+
+		"Removed:\n"+
+		"\tpkg/internal/x.go\n"+
+		"Changed:\n"+
+		"\tREADME.md\n"+
+		"\n"+
+		"Diff:\n"+
+		"--- expected/README.md\n"+
+		"+++ actual/README.md\n"+
+		"@@ -1 +1,3 @@\n"+
+		" # Example\n"+
+		"+\n"+
+		"+More.\n")
+
+	res = DirTreeEqual(mockT, fstest.MapFS{"logo.png": {Data: []byte{0x89, 'P'}}}, fstest.MapFS{"logo.png": {Data: []byte{0x89, 'Q'}}})
+	mockT.checkResultAndErrMsg(t, false, res, "Directory trees are not equal:\n"+
+		"Changed:\n"+
+		"\tlogo.png\n"+
+		"\n"+
+		"Diff:\n"+
+		"Binary file logo.png differs\n")
+}
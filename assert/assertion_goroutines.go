@@ -0,0 +1,183 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultIgnoredGoroutines lists functions of goroutines started by the runtime
+// and the testing package, which are never reported as leaks.
+var defaultIgnoredGoroutines = []string{
+	"testing.tRunner",
+	"testing.RunTests",
+	"testing.runTests",
+	"testing.(*M).Run",
+	"testing.(*T).Run",
+	"os/signal.signal_recv",
+	"os/signal.loop",
+	"runtime.ensureSigM",
+	"runtime/trace.Start.func1",
+}
+
+// goroutine describes a goroutine parsed from the output of runtime.Stack.
+type goroutine struct {
+	id    int64
+	funcs []string
+	stack string
+}
+
+// hasFunc returns true if any frame of the goroutine's stack is one of funcs.
+// Frames are matched on the function name only, "created by" lines are not
+// considered.
+func (g goroutine) hasFunc(funcs []string) bool {
+	for _, f := range g.funcs {
+		for _, ignored := range funcs {
+			if f == ignored {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runningGoroutines returns all the goroutines that are currently running.
+// The first one returned is always the calling goroutine.
+func runningGoroutines() []goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var goroutines []goroutine
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		lines := strings.Split(stack, "\n")
+		// The header has the form "goroutine 18 [chan receive]:"
+		fields := strings.Fields(lines[0])
+		if len(fields) < 2 || fields[0] != "goroutine" {
+			continue
+		}
+		id, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		g := goroutine{id: id, stack: stack}
+		for _, line := range lines[1:] {
+			if strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "created by ") {
+				continue
+			}
+			if i := strings.LastIndex(line, "("); i > 0 {
+				line = line[:i]
+			}
+			g.funcs = append(g.funcs, line)
+		}
+		goroutines = append(goroutines, g)
+	}
+	return goroutines
+}
+
+// GoroutineSnapshot records the goroutines that were running at a point in
+// time, so that NoGoroutineLeaks can tell the goroutines started later apart.
+type GoroutineSnapshot struct {
+	ids    map[int64]bool
+	ignore []string
+}
+
+// SnapshotGoroutines records the goroutines that are currently running.
+//
+//	snapshot := assert.SnapshotGoroutines()
+//	defer assert.NoGoroutineLeaks(t, snapshot, time.Second, 10*time.Millisecond)
+func SnapshotGoroutines() *GoroutineSnapshot {
+	s := &GoroutineSnapshot{ids: make(map[int64]bool)}
+	for _, g := range runningGoroutines() {
+		s.ids[g.id] = true
+	}
+	return s
+}
+
+// IgnoreGoroutines makes NoGoroutineLeaks ignore the goroutines that have any
+// of the given functions in their stack, for example
+// "net/http.(*persistConn).readLoop". It returns the snapshot so calls can be
+// chained.
+func (s *GoroutineSnapshot) IgnoreGoroutines(funcs ...string) *GoroutineSnapshot {
+	s.ignore = append(s.ignore, funcs...)
+	return s
+}
+
+// IgnoreLeaked makes NoGoroutineLeaks ignore the goroutines that are leaked
+// with regard to other at the time of the call, for example the ones already
+// reported by a NoGoroutineLeaks with other. It returns the snapshot so calls
+// can be chained.
+func (s *GoroutineSnapshot) IgnoreLeaked(other *GoroutineSnapshot) *GoroutineSnapshot {
+	for _, g := range other.leaked() {
+		s.ids[g.id] = true
+	}
+	return s
+}
+
+// leaked returns the running goroutines that are neither in the snapshot nor
+// ignored. The calling goroutine is never reported. A nil snapshot reports
+// every goroutine that is not ignored by default.
+func (s *GoroutineSnapshot) leaked() []goroutine {
+	var leaks []goroutine
+	for i, g := range runningGoroutines() {
+		if i == 0 || g.hasFunc(defaultIgnoredGoroutines) {
+			continue
+		}
+		if s != nil && (s.ids[g.id] || g.hasFunc(s.ignore)) {
+			continue
+		}
+		leaks = append(leaks, g)
+	}
+	return leaks
+}
+
+// NoGoroutineLeaks asserts that all the goroutines started after snapshot was
+// taken stop running within waitFor, checking them each tick. Goroutines of
+// the runtime and of the testing package are ignored. If snapshot is nil,
+// every other goroutine is considered to be leaked. Goroutines started by
+// parallel tests running at the same time are reported as well.
+//
+//	snapshot := assert.SnapshotGoroutines()
+//	server.Start()
+//	server.Stop()
+//	assert.NoGoroutineLeaks(t, snapshot, time.Second, 10*time.Millisecond)
+func NoGoroutineLeaks(t TestingT, snapshot *GoroutineSnapshot, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	leaks := snapshot.leaked()
+	if len(leaks) == 0 {
+		return true
+	}
+
+	timer := time.NewTimer(waitFor)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			stacks := make([]string, len(leaks))
+			for i, g := range leaks {
+				stacks[i] = g.stack
+			}
+			return Fail(t, fmt.Sprintf("Found %d leaked goroutine(s) after %v:\n\n%s", len(leaks), waitFor, strings.Join(stacks, "\n\n")), msgAndArgs...)
+		case <-ticker.C:
+			if leaks = snapshot.leaked(); len(leaks) == 0 {
+				return true
+			}
+		}
+	}
+}
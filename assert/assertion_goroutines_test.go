@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func blockUntilClosed(ch chan struct{}) {
+	<-ch
+}
+
+// The goroutine leak tests don't call t.Parallel(), as goroutines started by
+// other tests would be reported as leaks.
+func TestNoGoroutineLeaks(t *testing.T) {
+	snapshot := SnapshotGoroutines()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		blockUntilClosed(stop)
+	}()
+
+	mockT := new(captureTestingT)
+	False(t, NoGoroutineLeaks(mockT, snapshot, 50*time.Millisecond, 10*time.Millisecond))
+	Contains(t, mockT.msg, "Found 1 leaked goroutine(s) after 50ms")
+	Contains(t, mockT.msg, "assert.blockUntilClosed")
+
+	close(stop)
+	<-done
+	True(t, NoGoroutineLeaks(t, snapshot, 50*time.Millisecond, 10*time.Millisecond))
+}
+
+func TestNoGoroutineLeaksGracePeriod(t *testing.T) {
+	snapshot := SnapshotGoroutines()
+
+	go time.Sleep(30 * time.Millisecond)
+
+	True(t, NoGoroutineLeaks(t, snapshot, time.Second, 10*time.Millisecond))
+}
+
+func TestNoGoroutineLeaksIgnore(t *testing.T) {
+	snapshot := SnapshotGoroutines().IgnoreGoroutines("github.com/stretchr/testify/assert.blockUntilClosed")
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go blockUntilClosed(stop)
+
+	True(t, NoGoroutineLeaks(t, snapshot, 50*time.Millisecond, 10*time.Millisecond))
+}
+
+func TestNoGoroutineLeaksIgnoreLeaked(t *testing.T) {
+	snapshot := SnapshotGoroutines()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	testSnapshot := SnapshotGoroutines()
+	go blockUntilClosed(stop)
+
+	mockT := new(captureTestingT)
+	False(t, NoGoroutineLeaks(mockT, testSnapshot, 50*time.Millisecond, 10*time.Millisecond))
+	snapshot.IgnoreLeaked(testSnapshot)
+	True(t, NoGoroutineLeaks(t, snapshot, 50*time.Millisecond, 10*time.Millisecond))
+}
+
+func TestRunningGoroutines(t *testing.T) {
+	t.Parallel()
+
+	goroutines := runningGoroutines()
+	True(t, len(goroutines) > 0)
+	True(t, goroutines[0].hasFunc([]string{"github.com/stretchr/testify/assert.TestRunningGoroutines"}))
+	True(t, goroutines[0].hasFunc([]string{"testing.tRunner"}))
+	True(t, strings.HasPrefix(goroutines[0].stack, "goroutine "))
+}
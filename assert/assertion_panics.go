@@ -0,0 +1,177 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PanicInfo describes a panic recovered by CapturePanic.
+type PanicInfo struct {
+	// Value is the value that was passed to panic.
+	Value interface{}
+	// Stack is the stack of the panicking goroutine, trimmed to the frames
+	// of the function passed to CapturePanic.
+	Stack string
+}
+
+// userPanicStack trims a stack captured by didPanic to the frames of the
+// function that panicked: the frames of debug.Stack, of the deferred recover
+// and of the runtime panic handling at the top, and the frames of didPanic and
+// its callers at the bottom, are removed. The stack is returned unchanged if
+// it doesn't have the expected shape.
+func userPanicStack(stack string) string {
+	lines := strings.Split(strings.TrimRight(stack, "\n"), "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "goroutine ") {
+		return stack
+	}
+
+	// Group the lines by frame: the function, then its location
+	var frames [][]string
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "\t") && len(frames) > 0 {
+			frames[len(frames)-1] = append(frames[len(frames)-1], line)
+			continue
+		}
+		frames = append(frames, []string{line})
+	}
+
+	frameFunc := func(frame []string) string {
+		name := frame[0]
+		if i := strings.LastIndex(name, "("); i > 0 {
+			name = name[:i]
+		}
+		return name
+	}
+
+	start := 0
+	for ; start < len(frames); start++ {
+		name := frameFunc(frames[start])
+		if name != "panic" && !strings.HasPrefix(name, "runtime.") && !strings.HasPrefix(name, "runtime/debug.") &&
+			name != "github.com/stretchr/testify/assert.didPanic.func1" {
+			break
+		}
+	}
+	end := start
+	for ; end < len(frames); end++ {
+		if frameFunc(frames[end]) == "github.com/stretchr/testify/assert.didPanic" {
+			break
+		}
+	}
+	if end == len(frames) {
+		return stack
+	}
+
+	trimmed := []string{lines[0]}
+	for _, frame := range frames[start:end] {
+		trimmed = append(trimmed, frame...)
+	}
+	return strings.Join(trimmed, "\n") + "\n"
+}
+
+// panicMessage returns the text of a recovered panic value: the message of
+// an error, or the value formatted with %v.
+func panicMessage(value interface{}) string {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(value)
+}
+
+// CapturePanic asserts that the code inside the specified PanicTestFunc
+// panics, and returns the recovered value and the stack of the panic so that
+// they can be checked further.
+//
+//	info, ok := assert.CapturePanic(t, func(){ GoCrazy() })
+//	if ok {
+//		assert.Contains(t, info.Stack, "GoCrazy")
+//	}
+func CapturePanic(t TestingT, f PanicTestFunc, msgAndArgs ...interface{}) (PanicInfo, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	funcDidPanic, panicValue, panickedStack := didPanic(f)
+	if !funcDidPanic {
+		return PanicInfo{}, Fail(t, fmt.Sprintf("func %#v should panic\n\tPanic value:\t%#v", f, panicValue), msgAndArgs...)
+	}
+
+	return PanicInfo{Value: panicValue, Stack: panickedStack}, true
+}
+
+// PanicsWithMatch asserts that the code inside the specified PanicTestFunc
+// panics, and that match returns true for the recovered panic value.
+//
+//	assert.PanicsWithMatch(t, func(){ GoCrazy() }, func(recovered interface{}) bool {
+//		code, ok := recovered.(int)
+//		return ok && code >= 500
+//	})
+func PanicsWithMatch(t TestingT, f PanicTestFunc, match func(recovered interface{}) bool, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	funcDidPanic, panicValue, panickedStack := didPanic(f)
+	if !funcDidPanic {
+		return Fail(t, fmt.Sprintf("func %#v should panic\n\tPanic value:\t%#v", f, panicValue), msgAndArgs...)
+	}
+	if !match(panicValue) {
+		return Fail(t, fmt.Sprintf("func %#v should panic with a value accepted by the match function\n\tPanic value:\t%#v\n\tPanic stack:\t%s", f, panicValue, panickedStack), msgAndArgs...)
+	}
+
+	return true
+}
+
+// PanicsWithErrorIs asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value is an error with target in its
+// tree, as reported by ErrorIs.
+//
+//	assert.PanicsWithErrorIs(t, io.ErrUnexpectedEOF, func(){ GoCrazy() })
+func PanicsWithErrorIs(t TestingT, target error, f PanicTestFunc, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	funcDidPanic, panicValue, panickedStack := didPanic(f)
+	if !funcDidPanic {
+		return Fail(t, fmt.Sprintf("func %#v should panic\n\tPanic value:\t%#v", f, panicValue), msgAndArgs...)
+	}
+	panicErr, isError := panicValue.(error)
+	if !isError || !errorTreeIs(panicErr, target) {
+		var expectedText string
+		if target != nil {
+			expectedText = target.Error()
+		}
+		msg := fmt.Sprintf("func %#v should panic with an error in chain:\t%#v\n", f, expectedText)
+		if isError {
+			msg += fmt.Sprintf("\tError chain:\t%s\n", buildErrorChainString(panicErr, false))
+		}
+		msg += fmt.Sprintf("\tPanic value:\t%#v\n", panicValue)
+		msg += fmt.Sprintf("\tPanic stack:\t%s\n", panickedStack)
+		return Fail(t, msg, msgAndArgs...)
+	}
+
+	return true
+}
+
+// PanicsWithRegexp asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value matches the regexp rx. If the
+// value is an error, its message is matched; any other value is formatted
+// with %v.
+//
+//	assert.PanicsWithRegexp(t, `^index out of range`, func(){ GoCrazy() })
+func PanicsWithRegexp(t TestingT, rx interface{}, f PanicTestFunc, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	funcDidPanic, panicValue, panickedStack := didPanic(f)
+	if !funcDidPanic {
+		return Fail(t, fmt.Sprintf("func %#v should panic\n\tPanic value:\t%#v", f, panicValue), msgAndArgs...)
+	}
+	r := compileRegexp(rx)
+	if msg := panicMessage(panicValue); !r.MatchString(msg) {
+		return Fail(t, fmt.Sprintf("func %#v should panic with a message matching:\t%q\n\tPanic message:\t%q\n\tPanic value:\t%#v\n\tPanic stack:\t%s", f, r, msg, panicValue, panickedStack), msgAndArgs...)
+	}
+
+	return true
+}
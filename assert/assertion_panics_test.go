@@ -0,0 +1,116 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func panicWithCode(code int) {
+	panic(code)
+}
+
+func panicOnNilMap() {
+	var m map[string]int
+	m["key"] = 1
+}
+
+func TestUserPanicStack(t *testing.T) {
+	t.Parallel()
+
+	_, _, stack := didPanic(func() { panicWithCode(500) })
+
+	lines := strings.Split(strings.TrimSuffix(stack, "\n"), "\n")
+	if Len(t, lines, 5, stack) {
+		True(t, strings.HasPrefix(lines[0], "goroutine "))
+		True(t, strings.HasPrefix(lines[1], "github.com/stretchr/testify/assert.panicWithCode("))
+		True(t, strings.HasPrefix(lines[3], "github.com/stretchr/testify/assert.TestUserPanicStack.func1("))
+	}
+	NotContains(t, stack, "runtime/debug.Stack")
+	NotContains(t, stack, "assert.didPanic")
+	NotContains(t, stack, "testing.tRunner")
+
+	_, _, stack = didPanic(panicOnNilMap)
+	lines = strings.Split(stack, "\n")
+	True(t, strings.HasPrefix(lines[1], "github.com/stretchr/testify/assert.panicOnNilMap("), stack)
+
+	Equal(t, "not a stack", userPanicStack("not a stack"))
+}
+
+func TestCapturePanic(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+
+	info, ok := CapturePanic(mockT, func() { panicWithCode(500) })
+	True(t, ok)
+	Equal(t, 500, info.Value)
+	Contains(t, info.Stack, "assert.panicWithCode(")
+
+	info, ok = CapturePanic(mockT, func() {})
+	Equal(t, PanicInfo{}, info)
+	False(t, ok)
+	Contains(t, mockT.msg, "should panic")
+}
+
+func TestPanicsWithMatch(t *testing.T) {
+	t.Parallel()
+
+	serverError := func(recovered interface{}) bool {
+		code, ok := recovered.(int)
+		return ok && code >= 500
+	}
+
+	mockT := new(captureTestingT)
+	True(t, PanicsWithMatch(mockT, func() { panicWithCode(503) }, serverError))
+
+	False(t, PanicsWithMatch(mockT, func() {}, serverError))
+	Contains(t, mockT.msg, "should panic\n")
+
+	False(t, PanicsWithMatch(mockT, func() { panicWithCode(404) }, serverError))
+	Contains(t, mockT.msg, "should panic with a value accepted by the match function")
+	Contains(t, mockT.msg, "Panic value:\t404")
+	Contains(t, mockT.msg, "assert.panicWithCode(")
+}
+
+func TestPanicsWithErrorIs(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, PanicsWithErrorIs(mockT, io.EOF, func() {
+		panic(fmt.Errorf("read: %w", io.EOF))
+	}))
+
+	False(t, PanicsWithErrorIs(mockT, io.EOF, func() {}))
+	Contains(t, mockT.msg, "should panic\n")
+
+	False(t, PanicsWithErrorIs(mockT, io.EOF, func() {
+		panic(fmt.Errorf("write: %w", io.ErrClosedPipe))
+	}))
+	Contains(t, mockT.msg, "should panic with an error in chain:\t\"EOF\"")
+	Contains(t, mockT.msg, "Error chain:\t\"write: io: read/write on closed pipe\"")
+
+	False(t, PanicsWithErrorIs(mockT, io.EOF, func() {
+		panic("EOF")
+	}))
+	Contains(t, mockT.msg, "Panic value:\t\"EOF\"")
+	NotContains(t, mockT.msg, "Error chain:")
+}
+
+func TestPanicsWithRegexp(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, PanicsWithRegexp(mockT, `^assignment to entry in nil map`, panicOnNilMap))
+	True(t, PanicsWithRegexp(mockT, `code \d+`, func() { panic("code 42") }))
+	True(t, PanicsWithRegexp(mockT, `^broken$`, func() { panic(errors.New("broken")) }))
+
+	False(t, PanicsWithRegexp(mockT, `code`, func() {}))
+	Contains(t, mockT.msg, "should panic\n")
+
+	False(t, PanicsWithRegexp(mockT, `^code \d+$`, func() { panicWithCode(42) }))
+	Contains(t, mockT.msg, "should panic with a message matching:\t\"^code \\\\d+$\"")
+	Contains(t, mockT.msg, "Panic message:\t\"42\"")
+}
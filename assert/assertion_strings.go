@@ -0,0 +1,180 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/internal/difflib"
+)
+
+// LineMatchOption controls how LinesMatch normalizes each line before
+// comparing them. Options can be combined with the | operator.
+type LineMatchOption uint
+
+const (
+	// LinesExact compares the lines as they are.
+	LinesExact LineMatchOption = 0
+	// LinesIgnoreTrailingSpace ignores whitespace at the end of each line.
+	LinesIgnoreTrailingSpace LineMatchOption = 1 << 0
+	// LinesNormalizeCRLF treats "\r\n" line endings as "\n".
+	LinesNormalizeCRLF LineMatchOption = 1 << 1
+)
+
+// splitLinesForMatch splits s into lines and normalizes each of them according to opts.
+func splitLinesForMatch(s string, opts LineMatchOption) []string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if opts&LinesNormalizeCRLF != 0 {
+			line = strings.TrimSuffix(line, "\r")
+		}
+		if opts&LinesIgnoreTrailingSpace != 0 {
+			line = strings.TrimRightFunc(line, unicode.IsSpace)
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// linesDiff returns a unified diff of the expected and actual lines.
+func linesDiff(expected, actual []string) string {
+	a := make([]string, len(expected))
+	for i, line := range expected {
+		a[i] = line + "\n"
+	}
+	b := make([]string, len(actual))
+	for i, line := range actual {
+		b[i] = line + "\n"
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        a,
+		B:        b,
+		FromFile: "Expected",
+		FromDate: "",
+		ToFile:   "Actual",
+		ToDate:   "",
+		Context:  1,
+	})
+
+	return "\n\nDiff:\n" + diff
+}
+
+// stringDiff returns a line based unified diff of the expected and actual strings.
+func stringDiff(expected, actual string) string {
+	return linesDiff(strings.Split(expected, "\n"), strings.Split(actual, "\n"))
+}
+
+// HasPrefix asserts that the specified string begins with prefix.
+//
+//	assert.HasPrefix(t, "Hello World", "Hello")
+func HasPrefix(t TestingT, str, prefix string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if strings.HasPrefix(str, prefix) {
+		return true
+	}
+
+	head := str
+	if len(head) > len(prefix) {
+		// Keep the whole rune cut by the length of prefix
+		n := len(prefix)
+		for n < len(head) && !utf8.RuneStart(head[n]) {
+			n++
+		}
+		head = head[:n]
+	}
+	return Fail(t, "String does not start with the expected prefix"+stringDiff(prefix, head), msgAndArgs...)
+}
+
+// HasSuffix asserts that the specified string ends with suffix.
+//
+//	assert.HasSuffix(t, "Hello World", "World")
+func HasSuffix(t TestingT, str, suffix string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if strings.HasSuffix(str, suffix) {
+		return true
+	}
+
+	tail := str
+	if len(tail) > len(suffix) {
+		// Keep the whole rune cut by the length of suffix
+		start := len(tail) - len(suffix)
+		for start > 0 && !utf8.RuneStart(tail[start]) {
+			start--
+		}
+		tail = tail[start:]
+	}
+	return Fail(t, "String does not end with the expected suffix"+stringDiff(suffix, tail), msgAndArgs...)
+}
+
+// EqualFold asserts that two strings are equal under simple Unicode
+// case-folding, which is a more general form of case-insensitivity.
+//
+//	assert.EqualFold(t, "Hello World", "hello world")
+func EqualFold(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if strings.EqualFold(expected, actual) {
+		return true
+	}
+
+	return Fail(t, "Strings are not equal ignoring case"+stringDiff(expected, actual), msgAndArgs...)
+}
+
+// ContainsAny asserts that the specified string contains at least one of the
+// Unicode code points in chars.
+//
+//	assert.ContainsAny(t, "Hello World", "xyz!W")
+func ContainsAny(t TestingT, str, chars string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if strings.ContainsAny(str, chars) {
+		return true
+	}
+
+	return Fail(t, fmt.Sprintf("%s does not contain any of the characters in %#v", truncatingFormat("%#v", str), chars), msgAndArgs...)
+}
+
+// LinesMatch asserts that two strings are equal line by line. The opts
+// argument controls how lines are normalized before being compared, see
+// LinesIgnoreTrailingSpace and LinesNormalizeCRLF.
+//
+//	assert.LinesMatch(t, "a\nb\n", "a  \r\nb\r\n", assert.LinesIgnoreTrailingSpace|assert.LinesNormalizeCRLF)
+func LinesMatch(t TestingT, expected, actual string, opts LineMatchOption, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expectedLines := splitLinesForMatch(expected, opts)
+	actualLines := splitLinesForMatch(actual, opts)
+
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		if i >= len(expectedLines) || i >= len(actualLines) || expectedLines[i] != actualLines[i] {
+			return Fail(t, fmt.Sprintf("Lines do not match, first difference at line %d", i+1)+linesDiff(expectedLines, actualLines), msgAndArgs...)
+		}
+	}
+
+	return true
+}
+
+// EqualIgnoringWhitespace asserts that two strings are equal when all runs of
+// whitespace are considered equivalent and leading and trailing whitespace is
+// ignored.
+//
+//	assert.EqualIgnoringWhitespace(t, "Hello World", "  Hello\n\tWorld ")
+func EqualIgnoringWhitespace(t TestingT, expected, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if ObjectsAreEqual(strings.Fields(expected), strings.Fields(actual)) {
+		return true
+	}
+
+	return Fail(t, "Strings are not equal ignoring whitespace"+stringDiff(expected, actual), msgAndArgs...)
+}
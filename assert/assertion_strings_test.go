@@ -0,0 +1,140 @@
+package assert
+
+import (
+	"testing"
+)
+
+func TestHasPrefixHasSuffix(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+
+	True(t, HasPrefix(mockT, "Hello World", "Hello"))
+	True(t, HasPrefix(mockT, "Hello World", ""))
+	False(t, HasPrefix(mockT, "Hello World", "World"))
+	False(t, HasPrefix(mockT, "Hi", "Hello"))
+
+	True(t, HasSuffix(mockT, "Hello World", "World"))
+	True(t, HasSuffix(mockT, "Hello World", ""))
+	False(t, HasSuffix(mockT, "Hello World", "Hello"))
+	False(t, HasSuffix(mockT, "Hi", "World"))
+}
+
+func TestHasPrefixFailMessage(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	res := HasPrefix(mockT, "Hello World", "Help")
+	mockT.checkResultAndErrMsg(t, false, res, "String does not start with the expected prefix\n\n"+
+		"Diff:\n"+
+		"--- Expected\n"+
+		"+++ Actual\n"+
+		"@@ -1 +1 @@\n"+
+		"-Help\n"+
+		"+Hell\n")
+
+	mockT = new(captureTestingT)
+	res = HasSuffix(mockT, "Hello World", "Word")
+	mockT.checkResultAndErrMsg(t, false, res, "String does not end with the expected suffix\n\n"+
+		"Diff:\n"+
+		"--- Expected\n"+
+		"+++ Actual\n"+
+		"@@ -1 +1 @@\n"+
+		"-Word\n"+
+		"+orld\n")
+
+	// Multi-byte runes are not cut
+	mockT = new(captureTestingT)
+	res = HasPrefix(mockT, "Héllo", "He")
+	mockT.checkResultAndErrMsg(t, false, res, "String does not start with the expected prefix\n\n"+
+		"Diff:\n"+
+		"--- Expected\n"+
+		"+++ Actual\n"+
+		"@@ -1 +1 @@\n"+
+		"-He\n"+
+		"+Hé\n")
+
+	mockT = new(captureTestingT)
+	res = HasSuffix(mockT, "Hellé!", "o!")
+	mockT.checkResultAndErrMsg(t, false, res, "String does not end with the expected suffix\n\n"+
+		"Diff:\n"+
+		"--- Expected\n"+
+		"+++ Actual\n"+
+		"@@ -1 +1 @@\n"+
+		"-o!\n"+
+		"+é!\n")
+}
+
+func TestEqualFold(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+
+	True(t, EqualFold(mockT, "Hello World", "hello world"))
+	False(t, EqualFold(mockT, "Straße", "STRASSE"))
+	True(t, EqualFold(mockT, "Σ", "ς"))
+	False(t, EqualFold(mockT, "Hello", "World"))
+}
+
+func TestContainsAny(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+
+	True(t, ContainsAny(mockT, "Hello World", "xyz!W"))
+	False(t, ContainsAny(mockT, "Hello World", ""))
+
+	res := ContainsAny(mockT, "Hello World", "xyz")
+	mockT.checkResultAndErrMsg(t, false, res, `"Hello World" does not contain any of the characters in "xyz"`+"\n")
+}
+
+func TestLinesMatch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expected, actual string
+		opts             LineMatchOption
+		result           bool
+	}{
+		{"a\nb\n", "a\nb\n", LinesExact, true},
+		{"a\nb\n", "a\nb", LinesExact, false},
+		{"a\nb\n", "a\r\nb\r\n", LinesExact, false},
+		{"a\nb\n", "a\r\nb\r\n", LinesNormalizeCRLF, true},
+		{"a\nb\n", "a  \nb\t\n", LinesExact, false},
+		{"a\nb\n", "a  \nb\t\n", LinesIgnoreTrailingSpace, true},
+		{"a\nb\n", "a  \r\nb\r\n", LinesIgnoreTrailingSpace | LinesNormalizeCRLF, true},
+		{"a\nb\n", "  a\nb\n", LinesIgnoreTrailingSpace, false},
+	}
+
+	for _, c := range cases {
+		mockT := new(testing.T)
+		Equal(t, c.result, LinesMatch(mockT, c.expected, c.actual, c.opts), "LinesMatch(%q, %q, %d)", c.expected, c.actual, c.opts)
+	}
+}
+
+func TestLinesMatchFailMessage(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	res := LinesMatch(mockT, "a\nb\nc\nd", "a\nb\nC  \nd", LinesIgnoreTrailingSpace)
+	mockT.checkResultAndErrMsg(t, false, res, "Lines do not match, first difference at line 3\n\n"+
+		"Diff:\n"+
+		"--- Expected\n"+
+		"+++ Actual\n"+
+		"@@ -2,3 +2,3 @@\n"+
+		" b\n"+
+		"-c\n"+
+		"+C\n"+
+		" d\n")
+}
+
+func TestEqualIgnoringWhitespace(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+
+	True(t, EqualIgnoringWhitespace(mockT, "Hello World", "  Hello\n\tWorld "))
+	True(t, EqualIgnoringWhitespace(mockT, "", " \n\t"))
+	False(t, EqualIgnoringWhitespace(mockT, "Hello World", "HelloWorld"))
+	False(t, EqualIgnoringWhitespace(mockT, "Hello World", "Hello Earth"))
+}
@@ -3,6 +3,7 @@ package assert
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,15 +11,18 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"regexp/syntax"
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 	"unicode/utf8"
 
 	// Wrapper around gopkg.in/yaml.v3
 	"github.com/stretchr/testify/assert/yaml"
+	"github.com/stretchr/testify/clock"
 	"github.com/stretchr/testify/internal/difflib"
 	"github.com/stretchr/testify/internal/spew"
 )
@@ -376,7 +380,7 @@ func Fail(t TestingT, failureMessage string, msgAndArgs ...interface{}) bool {
 	// Add test name if the Go version supports it
 	if n, ok := t.(interface {
 		Name() string
-	}); ok {
+	}); ok && n.Name() != "" {
 		content = append(content, labeledContent{"Test", n.Name()})
 	}
 
@@ -1278,13 +1282,14 @@ func Condition(t TestingT, comp Comparison, msgAndArgs ...interface{}) bool {
 type PanicTestFunc func()
 
 // didPanic returns true if the function passed to it panics. Otherwise, it returns false.
+// The returned stack is trimmed to the frames of f.
 func didPanic(f PanicTestFunc) (didPanic bool, message interface{}, stack string) {
 	didPanic = true
 
 	defer func() {
 		message = recover()
 		if didPanic {
-			stack = string(debug.Stack())
+			stack = userPanicStack(string(debug.Stack()))
 		}
 	}()
 
@@ -1707,23 +1712,84 @@ func ErrorContains(t TestingT, theError error, contains string, msgAndArgs ...in
 	return true
 }
 
-// matchRegexp return true if a specified regexp matches a string.
-func matchRegexp(rx interface{}, str interface{}) bool {
-	var r *regexp.Regexp
+// compileRegexp returns rx if it is a *regexp.Regexp, otherwise it compiles
+// the string representation of rx.
+func compileRegexp(rx interface{}) *regexp.Regexp {
 	if rr, ok := rx.(*regexp.Regexp); ok {
-		r = rr
-	} else {
-		r = regexp.MustCompile(fmt.Sprint(rx))
+		return rr
 	}
+	return regexp.MustCompile(fmt.Sprint(rx))
+}
 
+// regexpInput returns the text a regexp should be matched against.
+func regexpInput(str interface{}) string {
 	switch v := str.(type) {
 	case []byte:
-		return r.Match(v)
+		return string(v)
 	case string:
-		return r.MatchString(v)
+		return v
 	default:
-		return r.MatchString(fmt.Sprint(v))
+		return fmt.Sprint(v)
+	}
+}
+
+// matchRegexp return true if a specified regexp matches a string.
+func matchRegexp(rx interface{}, str interface{}) bool {
+	r := compileRegexp(rx)
+
+	if v, ok := str.([]byte); ok {
+		return r.Match(v)
+	}
+	return r.MatchString(regexpInput(str))
+}
+
+// closestPartialMatch looks for the longest leading part of the pattern of r
+// that matches str, and describes where that partial match stops. It returns
+// an empty string if no leading part of the pattern matches.
+func closestPartialMatch(r *regexp.Regexp, str string) string {
+	re, err := syntax.Parse(r.String(), syntax.Perl)
+	if err != nil || re.Op != syntax.OpConcat {
+		return ""
+	}
+
+	for n := len(re.Sub) - 1; n > 0; n-- {
+		prefix := &syntax.Regexp{Op: syntax.OpConcat, Flags: re.Flags, Sub: re.Sub[:n]}
+		pr, err := regexp.Compile(prefix.String())
+		if err != nil {
+			continue
+		}
+		loc := pr.FindStringIndex(str)
+		if loc == nil {
+			continue
+		}
+		return fmt.Sprintf("\nClosest partial match: %q matched %q at offset %d, then failed at offset %d:\n%s",
+			prefix.String(), str[loc[0]:loc[1]], loc[0], loc[1], markOffset(str, loc[1]))
 	}
+	return ""
+}
+
+// markOffset returns the line of str that contains offset, followed by a line
+// with a caret pointing at offset.
+func markOffset(str string, offset int) string {
+	start := strings.LastIndex(str[:offset], "\n") + 1
+	end := strings.Index(str[offset:], "\n")
+	if end < 0 {
+		end = len(str)
+	} else {
+		end += offset
+	}
+
+	var marker strings.Builder
+	for _, c := range str[start:offset] {
+		if c == '\t' {
+			marker.WriteRune('\t')
+		} else {
+			marker.WriteRune(' ')
+		}
+	}
+	marker.WriteRune('^')
+
+	return str[start:end] + "\n" + marker.String()
 }
 
 // Regexp asserts that a specified regexp matches a string.
@@ -1761,6 +1827,76 @@ func NotRegexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interf
 	return !match
 }
 
+// RegexpGroups asserts that a specified regexp matches a string, and that the
+// named capture groups of the first match have the expected values.
+//
+//	assert.RegexpGroups(t, `(?P<key>\w+)=(?P<value>\w+)`, "level=debug", map[string]string{"key": "level", "value": "debug"})
+func RegexpGroups(t TestingT, rx interface{}, str interface{}, expectedGroups map[string]string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	r := compileRegexp(rx)
+	input := regexpInput(str)
+
+	for name := range expectedGroups {
+		if r.SubexpIndex(name) < 0 {
+			return Fail(t, fmt.Sprintf("Regexp \"%v\" has no capture group named %q", r, name), msgAndArgs...)
+		}
+	}
+
+	match := r.FindStringSubmatch(input)
+	if match == nil {
+		return Fail(t, fmt.Sprintf("Expect \"%v\" to match \"%v\"", input, r)+closestPartialMatch(r, input), msgAndArgs...)
+	}
+
+	equal := true
+	actualGroups := make(map[string]string, len(expectedGroups))
+	for name, expected := range expectedGroups {
+		actualGroups[name] = match[r.SubexpIndex(name)]
+		equal = equal && actualGroups[name] == expected
+	}
+
+	if !equal {
+		return Fail(t, fmt.Sprintf("Capture groups of \"%v\" matching \"%v\" are not equal:\n"+
+			"expected: %s\n"+
+			"actual  : %s%s", match[0], r, truncatingFormat("%#v", expectedGroups), truncatingFormat("%#v", actualGroups),
+			diff(expectedGroups, actualGroups)), msgAndArgs...)
+	}
+
+	return true
+}
+
+// RegexpMatchCount asserts that a specified regexp matches a string exactly
+// count times, without overlapping matches.
+//
+//	assert.RegexpMatchCount(t, "ERROR", logOutput, 2)
+func RegexpMatchCount(t TestingT, rx interface{}, str interface{}, count int, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	r := compileRegexp(rx)
+	input := regexpInput(str)
+
+	matches := r.FindAllStringIndex(input, -1)
+	if len(matches) == count {
+		return true
+	}
+
+	msg := fmt.Sprintf("Expect \"%v\" to match \"%v\" %d time(s), but it matched %d time(s)", input, r, count, len(matches))
+	if len(matches) == 0 {
+		msg += closestPartialMatch(r, input)
+	} else {
+		offsets := make([]string, len(matches))
+		for i, loc := range matches {
+			offsets[i] = fmt.Sprintf("%q at offset %d", input[loc[0]:loc[1]], loc[0])
+		}
+		msg += "\nMatches:\n" + truncatingFormat("%s", strings.Join(offsets, "\n"))
+	}
+	return Fail(t, msg, msgAndArgs...)
+}
+
 // Zero asserts that i is the zero value for its type.
 func Zero(t TestingT, i interface{}, msgAndArgs ...interface{}) bool {
 	if h, ok := t.(tHelper); ok {
@@ -2012,10 +2148,12 @@ func Eventually(t TestingT, condition func() bool, waitFor time.Duration, tick t
 	ch := make(chan bool, 1)
 	checkCond := func() { ch <- condition() }
 
-	timer := time.NewTimer(waitFor)
+	clk := clockFor(t)
+
+	timer := clk.NewTimer(waitFor)
 	defer timer.Stop()
 
-	ticker := time.NewTicker(tick)
+	ticker := clk.NewTicker(tick)
 	defer ticker.Stop()
 
 	var tickC <-chan time.Time
@@ -2025,7 +2163,7 @@ func Eventually(t TestingT, condition func() bool, waitFor time.Duration, tick t
 
 	for {
 		select {
-		case <-timer.C:
+		case <-timer.C():
 			return Fail(t, "Condition never satisfied", msgAndArgs...)
 		case <-tickC:
 			tickC = nil
@@ -2034,7 +2172,7 @@ func Eventually(t TestingT, condition func() bool, waitFor time.Duration, tick t
 			if v {
 				return true
 			}
-			tickC = ticker.C
+			tickC = ticker.C()
 		}
 	}
 }
@@ -2115,10 +2253,12 @@ func EventuallyWithT(t TestingT, condition func(collect *CollectT), waitFor time
 		condition(collect)
 	}
 
-	timer := time.NewTimer(waitFor)
+	clk := clockFor(t)
+
+	timer := clk.NewTimer(waitFor)
 	defer timer.Stop()
 
-	ticker := time.NewTicker(tick)
+	ticker := clk.NewTicker(tick)
 	defer ticker.Stop()
 
 	var tickC <-chan time.Time
@@ -2128,7 +2268,7 @@ func EventuallyWithT(t TestingT, condition func(collect *CollectT), waitFor time
 
 	for {
 		select {
-		case <-timer.C:
+		case <-timer.C():
 			for _, err := range lastFinishedTickErrs {
 				t.Errorf("%v", err)
 			}
@@ -2142,7 +2282,7 @@ func EventuallyWithT(t TestingT, condition func(collect *CollectT), waitFor time
 			}
 			// Keep the errors from the last ended condition, so that they can be copied to t if timeout is reached.
 			lastFinishedTickErrs = collect.errors
-			tickC = ticker.C
+			tickC = ticker.C()
 		}
 	}
 }
@@ -2159,10 +2299,12 @@ func Never(t TestingT, condition func() bool, waitFor time.Duration, tick time.D
 	ch := make(chan bool, 1)
 	checkCond := func() { ch <- condition() }
 
-	timer := time.NewTimer(waitFor)
+	clk := clockFor(t)
+
+	timer := clk.NewTimer(waitFor)
 	defer timer.Stop()
 
-	ticker := time.NewTicker(tick)
+	ticker := clk.NewTicker(tick)
 	defer ticker.Stop()
 
 	var tickC <-chan time.Time
@@ -2172,7 +2314,7 @@ func Never(t TestingT, condition func() bool, waitFor time.Duration, tick time.D
 
 	for {
 		select {
-		case <-timer.C:
+		case <-timer.C():
 			return true
 		case <-tickC:
 			tickC = nil
@@ -2181,11 +2323,185 @@ func Never(t TestingT, condition func() bool, waitFor time.Duration, tick time.D
 			if v {
 				return Fail(t, "Condition satisfied", msgAndArgs...)
 			}
-			tickC = ticker.C
+			tickC = ticker.C()
+		}
+	}
+}
+
+// TickBackoff makes EventuallyCtx, EventuallyWithTCtx and NeverCtx multiply
+// their tick by Factor after each check, up to MaxTick. A MaxTick of zero means
+// the tick is not capped. A nil *TickBackoff keeps the tick constant.
+//
+//	backoff := &assert.TickBackoff{Factor: 2, MaxTick: time.Second}
+//	assert.EventuallyCtx(t, ctx, isReady, time.Minute, 10*time.Millisecond, backoff)
+type TickBackoff struct {
+	Factor  float64
+	MaxTick time.Duration
+}
+
+// next returns the tick to wait for after tick.
+func (b *TickBackoff) next(tick time.Duration) time.Duration {
+	if b == nil {
+		return tick
+	}
+	next := time.Duration(float64(tick) * b.Factor)
+	if b.MaxTick > 0 && next > b.MaxTick {
+		next = b.MaxTick
+	}
+	return next
+}
+
+// pollCtx checks the condition once, then again a tick after each check has
+// finished, until check returns true, waitFor elapses or ctx is done. The tick
+// grows with backoff if backoff is not nil. The context passed to check is
+// done when polling stops. It returns whether check returned true and the
+// number of checks that finished. err is non-nil if ctx was done before
+// waitFor elapsed.
+func pollCtx(ctx context.Context, clk clock.Clock, check func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff) (satisfied bool, checks int, err error) {
+	pollingCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan bool, 1)
+	checkCond := func() { ch <- check(pollingCtx) }
+
+	waitTimer := clk.NewTimer(waitFor)
+	defer waitTimer.Stop()
+
+	timer := clk.NewTimer(tick)
+	defer timer.Stop()
+
+	var tickC <-chan time.Time
+
+	// Check the condition once first on the initial call.
+	go checkCond()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, checks, ctx.Err()
+		case <-waitTimer.C():
+			return false, checks, nil
+		case <-tickC:
+			tickC = nil
+			go checkCond()
+		case v := <-ch:
+			checks++
+			if v {
+				return true, checks, nil
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C():
+				default:
+				}
+			}
+			timer.Reset(tick)
+			tickC = timer.C()
+			tick = backoff.next(tick)
 		}
 	}
 }
 
+// EventuallyCtx asserts that given condition will be met in waitFor time,
+// periodically checking target function each tick. It stops early if ctx is
+// done. The condition is called with a context that is done once the
+// assertion gives up. The tick grows between checks as set by backoff, or is
+// constant if backoff is nil.
+//
+//	assert.EventuallyCtx(t, ctx, func(ctx context.Context) bool { return true }, time.Second, 10*time.Millisecond, nil)
+func EventuallyCtx(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	satisfied, checks, err := pollCtx(ctx, clockFor(t), condition, waitFor, tick, backoff)
+	if satisfied {
+		return true
+	}
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Condition never satisfied after %d check(s): %s", checks, err), msgAndArgs...)
+	}
+	return Fail(t, fmt.Sprintf("Condition never satisfied after %d check(s) in %v", checks, waitFor), msgAndArgs...)
+}
+
+// EventuallyWithTCtx asserts that given condition will be met in waitFor
+// time, periodically checking target function each tick, like
+// EventuallyWithT. It stops early if ctx is done. The condition is called with
+// a context that is done once the assertion gives up. If the condition is not
+// met, the collected errors of the last tick are copied to t. The tick grows
+// between checks as set by backoff, or is constant if backoff is nil.
+//
+//	assert.EventuallyWithTCtx(t, ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx)
+//		assert.NoError(c, err)
+//		assert.Equal(c, "ready", resp)
+//	}, 10*time.Second, 100*time.Millisecond, nil)
+func EventuallyWithTCtx(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *CollectT), waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	var mu sync.Mutex
+	var lastFinishedTickErrs []error
+
+	check := func(ctx context.Context) bool {
+		collect := new(CollectT)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			condition(ctx, collect)
+		}()
+		<-done
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !collect.failed() {
+			return true
+		}
+		// Keep the errors from the last ended condition, so that they can be copied to t if polling stops.
+		lastFinishedTickErrs = collect.errors
+		return false
+	}
+
+	satisfied, checks, err := pollCtx(ctx, clockFor(t), check, waitFor, tick, backoff)
+	if satisfied {
+		return true
+	}
+
+	mu.Lock()
+	for _, err := range lastFinishedTickErrs {
+		t.Errorf("%v", err)
+	}
+	mu.Unlock()
+
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Condition never satisfied after %d check(s): %s", checks, err), msgAndArgs...)
+	}
+	return Fail(t, fmt.Sprintf("Condition never satisfied after %d check(s) in %v", checks, waitFor), msgAndArgs...)
+}
+
+// NeverCtx asserts that the given condition doesn't satisfy in waitFor time,
+// periodically checking the target function each tick. It fails if ctx is
+// done before waitFor elapses. The condition is called with a context that is
+// done once the assertion stops checking. The tick grows between checks as set
+// by backoff, or is constant if backoff is nil.
+//
+//	assert.NeverCtx(t, ctx, func(ctx context.Context) bool { return false }, time.Second, 10*time.Millisecond, nil)
+func NeverCtx(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *TickBackoff, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	satisfied, checks, err := pollCtx(ctx, clockFor(t), condition, waitFor, tick, backoff)
+	if satisfied {
+		return Fail(t, fmt.Sprintf("Condition satisfied at check %d", checks), msgAndArgs...)
+	}
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Condition checked %d time(s) before context was done: %s", checks, err), msgAndArgs...)
+	}
+	return true
+}
+
 // ErrorIs asserts that at least one of the errors in err's chain matches target.
 // This is a wrapper for errors.Is.
 func ErrorIs(t TestingT, err, target error, msgAndArgs ...interface{}) bool {
@@ -2277,37 +2593,53 @@ func NotErrorAs(t TestingT, err error, target interface{}, msgAndArgs ...interfa
 	), msgAndArgs...)
 }
 
-func unwrapAll(err error) (errs []error) {
-	errs = append(errs, err)
+// errorTreeNode is an error of an error tree, with its indentation level.
+type errorTreeNode struct {
+	err   error
+	level int
+}
+
+// errorTree walks the tree of errors wrapped by err, depth-first, following
+// both Unwrap() error and Unwrap() []error. The errors wrapped by a single
+// Unwrap() error stay at the level of the wrapping error, while each of the
+// errors joined by Unwrap() []error is one level deeper.
+func errorTree(err error, level int) (nodes []errorTreeNode) {
+	nodes = append(nodes, errorTreeNode{err: err, level: level})
 	switch x := err.(type) {
 	case interface{ Unwrap() error }:
 		err = x.Unwrap()
 		if err == nil {
 			return
 		}
-		errs = append(errs, unwrapAll(err)...)
+		if level == 0 {
+			level = 1
+		}
+		nodes = append(nodes, errorTree(err, level)...)
 	case interface{ Unwrap() []error }:
 		for _, err := range x.Unwrap() {
-			errs = append(errs, unwrapAll(err)...)
+			if err != nil {
+				nodes = append(nodes, errorTree(err, level+1)...)
+			}
 		}
 	}
 	return
 }
 
+// buildErrorChainString renders the tree of errors wrapped by err, one error
+// per line, indented by one tab for each level of the tree.
 func buildErrorChainString(err error, withType bool) string {
 	if err == nil {
 		return ""
 	}
 
 	var chain string
-	errs := unwrapAll(err)
-	for i := range errs {
+	for i, node := range errorTree(err, 0) {
 		if i != 0 {
-			chain += "\n\t"
+			chain += "\n" + strings.Repeat("\t", node.level)
 		}
-		chain += fmt.Sprintf("%q", errs[i].Error())
+		chain += fmt.Sprintf("%q", node.err.Error())
 		if withType {
-			chain += fmt.Sprintf(" (%T)", errs[i])
+			chain += fmt.Sprintf(" (%T)", node.err)
 		}
 	}
 	return chain
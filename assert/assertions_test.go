@@ -3,6 +3,7 @@ package assert
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -2557,6 +2558,54 @@ func TestRegexp(t *testing.T) {
 	}
 }
 
+func TestRegexpGroups(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+	rx := `(?P<key>\w+)=(?P<value>\w+)`
+
+	True(t, RegexpGroups(mockT, rx, "level=debug", map[string]string{"key": "level", "value": "debug"}))
+	True(t, RegexpGroups(mockT, regexp.MustCompile(rx), []byte("level=debug"), map[string]string{"value": "debug"}))
+	True(t, RegexpGroups(mockT, rx, "level=debug", nil))
+	False(t, RegexpGroups(mockT, rx, "level=debug", map[string]string{"value": "info"}))
+	False(t, RegexpGroups(mockT, rx, "level=debug", map[string]string{"missing": "debug"}))
+	False(t, RegexpGroups(mockT, rx, "level: debug", map[string]string{"key": "level"}))
+}
+
+func TestRegexpGroupsFailMessage(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	res := RegexpGroups(mockT, `(?P<key>[a-z]+)=(?P<value>\d+);`, "x level=debug;", map[string]string{"key": "level"})
+	mockT.checkResultAndErrMsg(t, false, res, "Expect \"x level=debug;\" to match \"(?P<key>[a-z]+)=(?P<value>\\d+);\"\n"+
+		"Closest partial match: \"(?P<key>[a-z]+)=\" matched \"level=\" at offset 2, then failed at offset 8:\n"+
+		"x level=debug;\n"+
+		"        ^\n")
+
+	mockT = new(captureTestingT)
+	res = RegexpGroups(mockT, `(?P<key>\w+)=(?P<value>\w+)`, "level=debug", map[string]string{"other": "x"})
+	mockT.checkResultAndErrMsg(t, false, res, "Regexp \"(?P<key>\\w+)=(?P<value>\\w+)\" has no capture group named \"other\"\n")
+}
+
+func TestRegexpMatchCount(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+
+	True(t, RegexpMatchCount(mockT, "ERROR", "ERROR a\nINFO b\nERROR c", 2))
+	True(t, RegexpMatchCount(mockT, regexp.MustCompile("aa"), []byte("aaaa"), 2))
+	True(t, RegexpMatchCount(mockT, "ERROR", "INFO b", 0))
+	False(t, RegexpMatchCount(mockT, "ERROR", "ERROR a\nINFO b\nERROR c", 1))
+	False(t, RegexpMatchCount(mockT, "ERROR", "INFO b", 1))
+
+	captureT := new(captureTestingT)
+	res := RegexpMatchCount(captureT, "ERR", "ERR x ERR", 1)
+	captureT.checkResultAndErrMsg(t, false, res, "Expect \"ERR x ERR\" to match \"ERR\" 1 time(s), but it matched 2 time(s)\n"+
+		"Matches:\n"+
+		"\"ERR\" at offset 0\n"+
+		"\"ERR\" at offset 6\n")
+}
+
 func testAutogeneratedFunction() {
 	defer func() {
 		if err := recover(); err == nil {
@@ -3634,6 +3683,140 @@ func TestNeverFailQuickly(t *testing.T) {
 	False(t, Never(mockT, condition, 100*time.Millisecond, time.Second))
 }
 
+func TestEventuallyCtx(t *testing.T) {
+	t.Parallel()
+
+	counter := 0
+	condition := func(ctx context.Context) bool {
+		counter++
+		return counter == 3
+	}
+
+	True(t, EventuallyCtx(t, context.Background(), condition, 100*time.Millisecond, 5*time.Millisecond, nil))
+	Equal(t, 3, counter)
+
+	mockT := new(captureTestingT)
+	res := EventuallyCtx(mockT, context.Background(), func(context.Context) bool { return false }, 10*time.Millisecond, time.Second, nil)
+	mockT.checkResultAndErrMsg(t, false, res, "Condition never satisfied after 1 check(s) in 10ms\n")
+}
+
+func TestEventuallyCtxCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockT := new(captureTestingT)
+	res := EventuallyCtx(mockT, ctx, func(ctx context.Context) bool {
+		<-ctx.Done()
+		return false
+	}, time.Minute, time.Millisecond, nil)
+	mockT.checkResultAndErrMsg(t, false, res, "Condition never satisfied after 0 check(s): context canceled\n")
+}
+
+func TestEventuallyCtxPassesContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conditionDone := make(chan struct{})
+	condition := func(ctx context.Context) bool {
+		// Blocks until the assertion gives up.
+		<-ctx.Done()
+		close(conditionDone)
+		return false
+	}
+
+	False(t, EventuallyCtx(new(testing.T), ctx, condition, 10*time.Millisecond, time.Millisecond, nil))
+	select {
+	case <-conditionDone:
+	case <-time.After(time.Second):
+		t.Error("the context passed to the condition should be done")
+	}
+}
+
+func TestEventuallyCtxBackoff(t *testing.T) {
+	t.Parallel()
+
+	var checks []time.Time
+	condition := func(ctx context.Context) bool {
+		checks = append(checks, time.Now())
+		return len(checks) == 4
+	}
+
+	backoff := &TickBackoff{Factor: 2, MaxTick: 40 * time.Millisecond}
+	True(t, EventuallyCtx(t, context.Background(), condition, time.Second, 10*time.Millisecond, backoff))
+	Len(t, checks, 4)
+	// Ticks are 10ms, 20ms and then 40ms.
+	GreaterOrEqual(t, checks[3].Sub(checks[2]), 40*time.Millisecond)
+	GreaterOrEqual(t, checks[3].Sub(checks[0]), 70*time.Millisecond)
+
+	b := &TickBackoff{Factor: 3, MaxTick: 50 * time.Millisecond}
+	Equal(t, 30*time.Millisecond, b.next(10*time.Millisecond))
+	Equal(t, 50*time.Millisecond, b.next(30*time.Millisecond))
+	Equal(t, 10*time.Millisecond, (*TickBackoff)(nil).next(10*time.Millisecond))
+}
+
+func TestEventuallyWithTCtx(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(errorsCapturingT)
+
+	counter := 0
+	condition := func(ctx context.Context, collect *CollectT) {
+		counter++
+		True(collect, counter == 2)
+	}
+
+	True(t, EventuallyWithTCtx(mockT, context.Background(), condition, 100*time.Millisecond, 5*time.Millisecond, nil))
+	Len(t, mockT.errors, 0)
+	Equal(t, 2, counter, "Condition is expected to be called 2 times")
+
+	condition = func(ctx context.Context, collect *CollectT) {
+		Fail(collect, "condition fixed failure")
+	}
+	False(t, EventuallyWithTCtx(mockT, context.Background(), condition, 50*time.Millisecond, 5*time.Millisecond, nil))
+	Len(t, mockT.errors, 2)
+	Contains(t, mockT.errors[0].Error(), "condition fixed failure")
+	Contains(t, mockT.errors[1].Error(), "Condition never satisfied after")
+}
+
+func TestEventuallyWithTCtxFailNow(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(errorsCapturingT)
+
+	condition := func(ctx context.Context, collect *CollectT) {
+		collect.FailNow()
+	}
+
+	False(t, EventuallyWithTCtx(mockT, context.Background(), condition, 50*time.Millisecond, 5*time.Millisecond, nil))
+	Len(t, mockT.errors, 1)
+}
+
+func TestNeverCtx(t *testing.T) {
+	t.Parallel()
+
+	True(t, NeverCtx(t, context.Background(), func(context.Context) bool { return false }, 50*time.Millisecond, 5*time.Millisecond, nil))
+
+	counter := 0
+	mockT := new(captureTestingT)
+	res := NeverCtx(mockT, context.Background(), func(context.Context) bool {
+		counter++
+		return counter == 2
+	}, time.Second, 5*time.Millisecond, nil)
+	mockT.checkResultAndErrMsg(t, false, res, "Condition satisfied at check 2\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res = NeverCtx(mockT, ctx, func(ctx context.Context) bool {
+		<-ctx.Done()
+		return false
+	}, time.Minute, 5*time.Millisecond, nil)
+	mockT.checkResultAndErrMsg(t, false, res, "Condition checked 0 time(s) before context was done: context canceled\n")
+}
+
 func Test_validateEqualArgs(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@
 // # Note
 //
 // All functions in this package return a bool value indicating whether the assertion has passed.
+// Some, such as [Receives], also return the value they checked, before the bool.
 //
 // # Example Usage
 //
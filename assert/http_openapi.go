@@ -0,0 +1,628 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert/yaml"
+)
+
+// openAPIMaxDepth limits the nesting of schemas followed while validating a
+// value, to stop on cycles of references.
+const openAPIMaxDepth = 64
+
+// OpenAPIDocument is an OpenAPI 3 document, to check that the responses of
+// handlers match the operations it declares. It is created with ParseOpenAPI.
+type OpenAPIDocument struct {
+	root       map[string]interface{}
+	basePaths  []string
+	operations []*openAPIOperation
+}
+
+// openAPIOperation is an operation declared in an OpenAPI document.
+type openAPIOperation struct {
+	id       string
+	method   string
+	template string
+	path     *regexp.Regexp
+	params   int
+	pointer  string
+	item     map[string]interface{}
+}
+
+func (o *openAPIOperation) String() string {
+	if o.id != "" {
+		return fmt.Sprintf("%q (%s %s)", o.id, o.method, o.template)
+	}
+	return fmt.Sprintf("%s %s", o.method, o.template)
+}
+
+// ParseOpenAPI parses an OpenAPI 3 document, in JSON or in YAML. YAML
+// documents use the [github.com/stretchr/testify/assert/yaml] indirection.
+func ParseOpenAPI(document []byte) (*OpenAPIDocument, error) {
+	var root interface{}
+	if trimmed := bytes.TrimSpace(document); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(document, &root); err != nil {
+			return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(document, &root); err != nil {
+			return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+		}
+		// Go through JSON so that the values have the same types as in JSON
+		// documents, and in the responses to validate.
+		content, err := json.Marshal(stringKeys(root))
+		if err != nil {
+			return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+		}
+		root = nil
+		if err := json.Unmarshal(content, &root); err != nil {
+			return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+		}
+	}
+
+	doc, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("parsing OpenAPI document: not an object")
+	}
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("parsing OpenAPI document: unsupported version %q, expected 3.x", version)
+	}
+
+	d := &OpenAPIDocument{root: doc}
+	servers, _ := doc["servers"].([]interface{})
+	for _, server := range servers {
+		serverURL, _ := server.(map[string]interface{})["url"].(string)
+		if u, err := url.Parse(serverURL); err == nil && strings.Trim(u.Path, "/") != "" {
+			d.basePaths = append(d.basePaths, "/"+strings.Trim(u.Path, "/"))
+		}
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for template, item := range paths {
+		item, _ := item.(map[string]interface{})
+		pattern, params := openAPIPathPattern(template)
+		for _, method := range []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"} {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, _ := operation["operationId"].(string)
+			d.operations = append(d.operations, &openAPIOperation{
+				id:       id,
+				method:   strings.ToUpper(method),
+				template: template,
+				path:     pattern,
+				params:   params,
+				pointer:  "#/paths/" + jsonPointerEscape(template) + "/" + method,
+				item:     operation,
+			})
+		}
+	}
+	// Prefer the operations with fewer path parameters, for templates that
+	// overlap like "/users/me" and "/users/{id}".
+	sort.Slice(d.operations, func(i, j int) bool {
+		a, b := d.operations[i], d.operations[j]
+		if a.params != b.params {
+			return a.params < b.params
+		}
+		return a.template < b.template
+	})
+	return d, nil
+}
+
+// stringKeys converts the maps decoded from YAML to maps with string keys.
+func stringKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = stringKeys(value)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = stringKeys(value)
+		}
+		return m
+	case []interface{}:
+		for i, value := range v {
+			v[i] = stringKeys(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+var openAPIPathParam = regexp.MustCompile(`\{[^}/]+\}`)
+
+// openAPIPathPattern returns the regexp matching the paths of a path template,
+// and the number of parameters in the template.
+func openAPIPathPattern(template string) (*regexp.Regexp, int) {
+	params := 0
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range openAPIPathParam.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString("[^/]+")
+		last = loc[1]
+		params++
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String()), params
+}
+
+func jsonPointerEscape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func jsonPointerUnescape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
+
+// operation returns the operation for a request, or nil if there is none.
+func (d *OpenAPIDocument) operation(method, path string) *openAPIOperation {
+	candidates := []string{path}
+	for _, base := range d.basePaths {
+		if strings.HasPrefix(path, base+"/") {
+			candidates = append(candidates, strings.TrimPrefix(path, base))
+		}
+	}
+	for _, o := range d.operations {
+		if o.method != method {
+			continue
+		}
+		for _, candidate := range candidates {
+			if o.path.MatchString(candidate) {
+				return o
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the value a local reference like
+// "#/components/schemas/User" points to.
+func (d *OpenAPIDocument) resolve(ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+	var value interface{} = d.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[jsonPointerUnescape(token)]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// check returns the ways a response does not match the document, and the
+// operation it was checked against.
+func (d *OpenAPIDocument) check(req *http.Request, status int, header http.Header, body []byte) (string, []string) {
+	o := d.operation(req.Method, req.URL.Path)
+	if o == nil {
+		return "", []string{fmt.Sprintf("no operation matches %s %s", req.Method, req.URL.Path)}
+	}
+
+	responses, _ := o.item["responses"].(map[string]interface{})
+	pointer := o.pointer + "/responses"
+	code := fmt.Sprint(status)
+	key := code
+	if _, ok := responses[key]; !ok {
+		key = code[:1] + "XX"
+	}
+	if _, ok := responses[key]; !ok {
+		key = "default"
+	}
+	response, ok := responses[key].(map[string]interface{})
+	if !ok {
+		declared := make([]string, 0, len(responses))
+		for key := range responses {
+			declared = append(declared, key)
+		}
+		sort.Strings(declared)
+		return o.String(), []string{fmt.Sprintf("status %d is not declared at %s (declared: %s)", status, pointer, strings.Join(declared, ", "))}
+	}
+	pointer += "/" + jsonPointerEscape(key)
+	if ref, _ := response["$ref"].(string); ref != "" {
+		if response, ok = d.resolveObject(ref); !ok {
+			return o.String(), []string{fmt.Sprintf("unresolved reference %q at %s", ref, pointer)}
+		}
+		pointer = ref
+	}
+
+	contentType := header.Get("Content-Type")
+	if len(body) == 0 && contentType == "" {
+		return o.String(), nil
+	}
+	content, _ := response["content"].(map[string]interface{})
+	pointer += "/content"
+	if len(content) == 0 {
+		return o.String(), []string{fmt.Sprintf("status %d declares no content at %s, found %d bytes of %q", status, pointer, len(body), contentType)}
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	mediaType = strings.ToLower(mediaType)
+	key = ""
+	for _, candidate := range []string{mediaType, strings.SplitN(mediaType, "/", 2)[0] + "/*", "*/*"} {
+		if _, ok := content[candidate]; ok {
+			key = candidate
+			break
+		}
+	}
+	if key == "" {
+		declared := make([]string, 0, len(content))
+		for key := range content {
+			declared = append(declared, key)
+		}
+		sort.Strings(declared)
+		return o.String(), []string{fmt.Sprintf("content type %q is not declared at %s (declared: %s)", mediaType, pointer, strings.Join(declared, ", "))}
+	}
+	pointer += "/" + jsonPointerEscape(key)
+
+	media, _ := content[key].(map[string]interface{})
+	schema, ok := media["schema"]
+	if !ok || !(mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return o.String(), nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return o.String(), []string{fmt.Sprintf("body is not valid JSON: %s", err)}
+	}
+	v := &openAPIValidator{doc: d}
+	v.validate(schema, pointer+"/schema", "$", value, 0)
+	return o.String(), v.errors
+}
+
+// resolveObject resolves a reference to an object.
+func (d *OpenAPIDocument) resolveObject(ref string) (map[string]interface{}, bool) {
+	value, ok := d.resolve(ref)
+	if !ok {
+		return nil, false
+	}
+	object, ok := value.(map[string]interface{})
+	return object, ok
+}
+
+// openAPIValidator validates values against the schemas of an OpenAPI
+// document. It supports the keywords of JSON Schema used for response
+// bodies, and collects an error for each mismatch.
+type openAPIValidator struct {
+	doc    *OpenAPIDocument
+	errors []string
+}
+
+func (v *openAPIValidator) fail(at, keyword, path, format string, args ...interface{}) {
+	v.errors = append(v.errors, fmt.Sprintf("%s: %s at %s/%s", path, fmt.Sprintf(format, args...), at, keyword))
+}
+
+// jsonType returns the JSON Schema type of a value decoded from JSON.
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func jsonText(value interface{}) string {
+	text, _ := json.Marshal(value)
+	return string(text)
+}
+
+// validate validates value, at path in the body, against schema, at pointer
+// in the document.
+func (v *openAPIValidator) validate(schema interface{}, pointer, path string, value interface{}, depth int) {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		if schema == false {
+			v.errors = append(v.errors, fmt.Sprintf("%s: no value is allowed at %s", path, pointer))
+		}
+		return
+	}
+	if depth > openAPIMaxDepth {
+		v.errors = append(v.errors, fmt.Sprintf("%s: schemas nested too deep at %s", path, pointer))
+		return
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		resolved, ok := v.doc.resolve(ref)
+		if !ok {
+			v.fail(pointer, "$ref", path, "unresolved reference %q", ref)
+			return
+		}
+		v.validate(resolved, ref, path, value, depth+1)
+		return
+	}
+
+	if !v.validateType(s, pointer, path, value) {
+		return
+	}
+	if enum, ok := s["enum"].([]interface{}); ok && !containsJSONValue(enum, value) {
+		v.fail(pointer, "enum", path, "%s is not one of %s", jsonText(value), jsonText(enum))
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		subschemas, ok := s[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+		matches := 0
+		for i, subschema := range subschemas {
+			sub := &openAPIValidator{doc: v.doc}
+			sub.validate(subschema, fmt.Sprintf("%s/%s/%d", pointer, keyword, i), path, value, depth+1)
+			if keyword == "allOf" {
+				v.errors = append(v.errors, sub.errors...)
+			} else if len(sub.errors) == 0 {
+				matches++
+			}
+		}
+		switch {
+		case keyword == "anyOf" && matches == 0:
+			v.fail(pointer, keyword, path, "matches none of the schemas")
+		case keyword == "oneOf" && matches != 1:
+			v.fail(pointer, keyword, path, "matches %d of the schemas instead of one", matches)
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(s, pointer, path, value, depth)
+	case []interface{}:
+		v.validateArray(s, pointer, path, value, depth)
+	case string:
+		length := utf8.RuneCountInString(value)
+		if limit, ok := s["minLength"].(float64); ok && float64(length) < limit {
+			v.fail(pointer, "minLength", path, "length %d is less than %v", length, limit)
+		}
+		if limit, ok := s["maxLength"].(float64); ok && float64(length) > limit {
+			v.fail(pointer, "maxLength", path, "length %d is greater than %v", length, limit)
+		}
+		if pattern, ok := s["pattern"].(string); ok {
+			if rx, err := regexp.Compile(pattern); err == nil && !rx.MatchString(value) {
+				v.fail(pointer, "pattern", path, "%q does not match %q", value, pattern)
+			}
+		}
+	case float64:
+		v.validateNumber(s, pointer, path, value)
+	}
+}
+
+// validateType validates the type of value, and reports whether the other
+// keywords apply.
+func (v *openAPIValidator) validateType(s map[string]interface{}, pointer, path string, value interface{}) bool {
+	var types []string
+	switch t := s["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, t := range t {
+			types = append(types, fmt.Sprint(t))
+		}
+	default:
+		return true
+	}
+
+	actual := jsonType(value)
+	if actual == "null" && s["nullable"] == true {
+		return false
+	}
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	v.fail(pointer, "type", path, "expected type %s, found %s", strings.Join(types, " or "), actual)
+	return false
+}
+
+func (v *openAPIValidator) validateObject(s map[string]interface{}, pointer, path string, value map[string]interface{}, depth int) {
+	required, _ := s["required"].([]interface{})
+	for _, name := range required {
+		name := fmt.Sprint(name)
+		if _, ok := value[name]; !ok {
+			v.fail(pointer, "required", path, "missing required property %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties, _ := s["properties"].(map[string]interface{})
+	for _, name := range names {
+		propertyPath := path + "." + name
+		if property, ok := properties[name]; ok {
+			v.validate(property, pointer+"/properties/"+jsonPointerEscape(name), propertyPath, value[name], depth+1)
+			continue
+		}
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(pointer, "additionalProperties", path, "unexpected property %q", name)
+			}
+		case map[string]interface{}:
+			v.validate(additional, pointer+"/additionalProperties", propertyPath, value[name], depth+1)
+		}
+	}
+}
+
+func (v *openAPIValidator) validateArray(s map[string]interface{}, pointer, path string, value []interface{}, depth int) {
+	if limit, ok := s["minItems"].(float64); ok && float64(len(value)) < limit {
+		v.fail(pointer, "minItems", path, "%d items is less than %v", len(value), limit)
+	}
+	if limit, ok := s["maxItems"].(float64); ok && float64(len(value)) > limit {
+		v.fail(pointer, "maxItems", path, "%d items is greater than %v", len(value), limit)
+	}
+	if items, ok := s["items"]; ok {
+		for i, item := range value {
+			v.validate(items, pointer+"/items", fmt.Sprintf("%s[%d]", path, i), item, depth+1)
+		}
+	}
+}
+
+func (v *openAPIValidator) validateNumber(s map[string]interface{}, pointer, path string, value float64) {
+	if limit, ok := s["minimum"].(float64); ok {
+		if s["exclusiveMinimum"] == true && value <= limit {
+			v.fail(pointer, "exclusiveMinimum", path, "%v is not greater than %v", value, limit)
+		} else if value < limit {
+			v.fail(pointer, "minimum", path, "%v is less than %v", value, limit)
+		}
+	}
+	if limit, ok := s["exclusiveMinimum"].(float64); ok && value <= limit {
+		v.fail(pointer, "exclusiveMinimum", path, "%v is not greater than %v", value, limit)
+	}
+	if limit, ok := s["maximum"].(float64); ok {
+		if s["exclusiveMaximum"] == true && value >= limit {
+			v.fail(pointer, "exclusiveMaximum", path, "%v is not less than %v", value, limit)
+		} else if value > limit {
+			v.fail(pointer, "maximum", path, "%v is greater than %v", value, limit)
+		}
+	}
+	if limit, ok := s["exclusiveMaximum"].(float64); ok && value >= limit {
+		v.fail(pointer, "exclusiveMaximum", path, "%v is not less than %v", value, limit)
+	}
+}
+
+func containsJSONValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if ObjectsAreEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesOpenAPI asserts that the response matches the operation declared
+// for its request in doc: that its status code, its content type and, for
+// JSON content, its body are declared. Failures name the operation and the
+// location in doc of what does not match.
+//
+//	doc, err := assert.ParseOpenAPI(spec)
+//	assert.HTTPRequest(router).URL("/users/1").Expect(t).MatchesOpenAPI(doc)
+func (r *HTTPResponse) MatchesOpenAPI(doc *OpenAPIDocument, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	operation, errs := doc.check(r.Request, r.Response.StatusCode, r.Response.Header, r.Body)
+	if len(errs) == 0 {
+		return true
+	}
+	msg := "Response does not match the OpenAPI document"
+	if operation != "" {
+		msg = "Response does not match OpenAPI operation " + operation
+	}
+	return r.fail(msg+":\n\t"+strings.Join(errs, "\n\t"), msgAndArgs)
+}
+
+// OpenAPIHandler returns a handler that calls handler, and asserts that each
+// of its responses matches the OpenAPI document doc like
+// HTTPResponse.MatchesOpenAPI does. It can be used with HTTPRequest and the
+// other HTTP assertions to check them against the document as well.
+//
+// The response is passed through as it is written, so streaming handlers and
+// http.Flusher keep working. It is also recorded, and checked once handler
+// returns.
+//
+//	contract := assert.OpenAPIHandler(t, doc, router)
+//	assert.HTTPSuccess(t, contract, "GET", "/users/1", nil)
+func OpenAPIHandler(t TestingT, doc *OpenAPIDocument, handler http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		tee := &teeResponseWriter{ResponseWriter: w, recorder: httptest.NewRecorder()}
+		handler.ServeHTTP(tee, req)
+		if !tee.wroteHeader {
+			tee.WriteHeader(http.StatusOK)
+		}
+
+		NewHTTPResponse(t, req, tee.recorder).MatchesOpenAPI(doc)
+	}
+}
+
+// teeResponseWriter passes a response through to an http.ResponseWriter, and
+// records it as well.
+type teeResponseWriter struct {
+	http.ResponseWriter
+	recorder    *httptest.ResponseRecorder
+	wroteHeader bool
+}
+
+func (w *teeResponseWriter) copyHeader() {
+	for key, values := range w.Header() {
+		w.recorder.Header()[key] = append([]string(nil), values...)
+	}
+}
+
+func (w *teeResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.copyHeader()
+	w.recorder.WriteHeader(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *teeResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		// The recorder sees the first write before the header is written, to
+		// detect the content type as it does for HTTPRequest.
+		w.wroteHeader = true
+		w.copyHeader()
+	}
+	_, _ = w.recorder.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes the response written so far, if the http.ResponseWriter
+// passed through to supports it.
+func (w *teeResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the http.ResponseWriter passed through to, for
+// http.ResponseController.
+func (w *teeResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,218 @@
+package assert
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const openAPIUsers = `
+openapi: 3.0.3
+info:
+  title: Users
+  version: "1.0"
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /users/me:
+    get:
+      operationId: getMe
+      responses:
+        200:
+          $ref: '#/components/responses/User'
+  /users/{id}:
+    get:
+      operationId: getUser
+      responses:
+        200:
+          $ref: '#/components/responses/User'
+        4XX:
+          description: Client error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [title]
+    delete:
+      responses:
+        204:
+          description: Deleted
+components:
+  responses:
+    User:
+      description: A user
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/User'
+  schemas:
+    User:
+      type: object
+      required: [id, name]
+      additionalProperties: false
+      properties:
+        id:
+          type: integer
+          minimum: 1
+        name:
+          type: string
+          minLength: 1
+        email:
+          type: string
+          nullable: true
+          pattern: '@'
+        roles:
+          type: array
+          items:
+            type: string
+            enum: [admin, user]
+`
+
+// httpUsers answers with the user in the "user" query parameter, or with an
+// error or HTML as asked by the other query parameters.
+func httpUsers(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Query().Get("status") != "":
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title": "Not found"}`)
+	case r.Method == "DELETE":
+		w.WriteHeader(http.StatusNoContent)
+	case r.URL.Query().Get("html") != "":
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<p>Ana</p>")
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, r.URL.Query().Get("user"))
+	}
+}
+
+func TestParseOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParseOpenAPI([]byte(openAPIUsers))
+	NoError(t, err)
+	Equal(t, "getMe", doc.operation("GET", "/users/me").id)
+	Equal(t, "getUser", doc.operation("GET", "/users/1").id)
+	Equal(t, "getUser", doc.operation("GET", "/v1/users/1").id)
+	Nil(t, doc.operation("POST", "/users/1"))
+
+	doc, err = ParseOpenAPI([]byte(`{"openapi": "3.1.0", "paths": {"/": {"get": {"operationId": "root"}}}}`))
+	NoError(t, err)
+	Equal(t, "root", doc.operation("GET", "/").id)
+
+	_, err = ParseOpenAPI([]byte(`{"swagger": "2.0"}`))
+	EqualError(t, err, `parsing OpenAPI document: unsupported version "", expected 3.x`)
+	_, err = ParseOpenAPI([]byte(`{`))
+	Error(t, err)
+}
+
+func TestHTTPResponseMatchesOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParseOpenAPI([]byte(openAPIUsers))
+	NoError(t, err)
+	users := http.HandlerFunc(httpUsers)
+
+	True(t, HTTPRequest(users).URL("/users/1").Query("user", `{"id": 1, "name": "Ana", "email": null, "roles": ["admin"]}`).Expect(t).MatchesOpenAPI(doc))
+	True(t, HTTPRequest(users).URL("/v1/users/me").Query("user", `{"id": 1, "name": "Ana"}`).Expect(t).MatchesOpenAPI(doc))
+	True(t, HTTPRequest(users).URL("/users/1").Query("status", "404").Expect(t).MatchesOpenAPI(doc))
+	True(t, HTTPRequest(users).Method("DELETE").URL("/users/1").Expect(t).MatchesOpenAPI(doc))
+
+	mockT := new(captureTestingT)
+	user := `{"id": 0.5, "name": "", "email": "ana", "roles": ["root"], "age": 30}`
+	res := HTTPRequest(users).URL("/users/1").Query("user", user).Expect(mockT).MatchesOpenAPI(doc)
+	False(t, res)
+	Contains(t, mockT.msg, `Response does not match OpenAPI operation "getUser" (GET /users/{id}):`)
+	Contains(t, mockT.msg, "$: unexpected property \"age\" at #/components/schemas/User/additionalProperties\n")
+	Contains(t, mockT.msg, "$.email: \"ana\" does not match \"@\" at #/components/schemas/User/properties/email/pattern\n")
+	Contains(t, mockT.msg, "$.id: expected type integer, found number at #/components/schemas/User/properties/id/type\n")
+	Contains(t, mockT.msg, "$.name: length 0 is less than 1 at #/components/schemas/User/properties/name/minLength\n")
+	Contains(t, mockT.msg, "$.roles[0]: \"root\" is not one of [\"admin\",\"user\"] at #/components/schemas/User/properties/roles/items/enum\n")
+
+	res = HTTPRequest(users).URL("/users/1").Query("user", `{"id": 1}`).Expect(mockT).MatchesOpenAPI(doc)
+	False(t, res)
+	Contains(t, mockT.msg, "$: missing required property \"name\" at #/components/schemas/User/required\n")
+
+	res = HTTPRequest(users).URL("/users/1").Query("html", "1").Expect(mockT).MatchesOpenAPI(doc)
+	False(t, res)
+	Contains(t, mockT.msg, "content type \"text/html\" is not declared at #/components/responses/User/content (declared: application/json)\n")
+
+	res = HTTPRequest(users).Method("DELETE").URL("/users/1").Query("status", "404").Expect(mockT).MatchesOpenAPI(doc)
+	False(t, res)
+	Contains(t, mockT.msg, "Response does not match OpenAPI operation DELETE /users/{id}:\n")
+	Contains(t, mockT.msg, "status 404 is not declared at #/paths/~1users~1{id}/delete/responses (declared: 204)\n")
+
+	res = HTTPRequest(users).URL("/users/me").Query("status", "404").Expect(mockT).MatchesOpenAPI(doc)
+	False(t, res)
+	Contains(t, mockT.msg, "status 404 is not declared at #/paths/~1users~1me/get/responses (declared: 200)\n")
+
+	res = HTTPRequest(users).URL("/groups").Expect(mockT).MatchesOpenAPI(doc)
+	False(t, res)
+	Contains(t, mockT.msg, "Response does not match the OpenAPI document:\n")
+	Contains(t, mockT.msg, "no operation matches GET /groups\n")
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParseOpenAPI([]byte(openAPIUsers))
+	NoError(t, err)
+
+	mockT := new(captureTestingT)
+	contract := OpenAPIHandler(mockT, doc, http.HandlerFunc(httpUsers))
+	True(t, HTTPBodyContains(mockT, contract, "GET", "/users/1", map[string][]string{"user": {`{"id": 1, "name": "Ana"}`}}, "Ana"))
+	Empty(t, mockT.msg)
+
+	True(t, HTTPSuccess(mockT, contract, "GET", "/users/1", map[string][]string{"user": {`{"id": "1", "name": "Ana"}`}}))
+	Contains(t, mockT.msg, "$.id: expected type integer, found string at #/components/schemas/User/properties/id/type")
+}
+
+func TestOpenAPIHandlerStreaming(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParseOpenAPI([]byte(openAPIUsers))
+	NoError(t, err)
+
+	w := httptest.NewRecorder()
+	mockT := new(captureTestingT)
+	contract := OpenAPIHandler(mockT, doc, http.HandlerFunc(func(tee http.ResponseWriter, r *http.Request) {
+		tee.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(tee, `{"id": 1, `)
+		flusher, ok := tee.(http.Flusher)
+		if True(t, ok, "the response writer should be a http.Flusher") {
+			flusher.Flush()
+		}
+		// The start of the body is passed through before the handler returns
+		Equal(t, `{"id": 1, `, w.Body.String())
+		fmt.Fprint(tee, `"name": "Ana"}`)
+	}))
+
+	contract(w, httptest.NewRequest("GET", "/users/1", nil))
+	True(t, w.Flushed)
+	Equal(t, http.StatusOK, w.Code)
+	Equal(t, "application/json", w.Header().Get("Content-Type"))
+	Equal(t, `{"id": 1, "name": "Ana"}`, w.Body.String())
+	Empty(t, mockT.msg)
+}
+
+func TestOpenAPIHandlerSniffedContentType(t *testing.T) {
+	t.Parallel()
+
+	doc, err := ParseOpenAPI([]byte(openAPIUsers))
+	NoError(t, err)
+
+	html := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<p>Ana</p>")
+	})
+	expectedT := new(captureTestingT)
+	False(t, HTTPRequest(html).URL("/users/1").Expect(expectedT).MatchesOpenAPI(doc))
+	Contains(t, expectedT.msg, "text/html; charset=utf-8")
+
+	mockT := new(captureTestingT)
+	w := httptest.NewRecorder()
+	OpenAPIHandler(mockT, doc, html)(w, httptest.NewRequest("GET", "/users/1", nil))
+	Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	Contains(t, mockT.msg, "text/html; charset=utf-8")
+}
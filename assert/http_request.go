@@ -0,0 +1,313 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// httpTransport is how an HTTPRequestBuilder sends its request.
+type httpTransport int
+
+const (
+	// inProcess calls the handler directly, with an httptest.ResponseRecorder.
+	inProcess httpTransport = iota
+	// liveServer sends the request to an httptest.Server around the handler.
+	liveServer
+	// liveTLSServer sends the request to an httptest.Server with TLS.
+	liveTLSServer
+	// liveHTTP2Server sends the request to an httptest.Server with TLS and
+	// HTTP/2.
+	liveHTTP2Server
+	// liveClient sends the request with an http.Client.
+	liveClient
+)
+
+// HTTPRequestBuilder builds an HTTP request to send to a handler, to check
+// the response it gets. It is created with HTTPRequest, and each of its
+// methods returns the builder so that calls can be chained.
+type HTTPRequestBuilder struct {
+	handler   http.Handler
+	transport httpTransport
+	client    *http.Client
+	baseURL   string
+	timeout   time.Duration
+
+	method  string
+	target  string
+	query   url.Values
+	header  http.Header
+	cookies []*http.Cookie
+	body    []byte
+	err     error
+}
+
+// HTTPRequest returns a builder for a request to send to handler. The request
+// is a GET request for "/" until set otherwise.
+//
+//	assert.HTTPRequest(router).
+//		Method("POST").
+//		URL("/users").
+//		Header("Authorization", "Bearer token").
+//		JSONBody(user).
+//		Expect(t).
+//		StatusCode(http.StatusCreated)
+func HTTPRequest(handler http.Handler) *HTTPRequestBuilder {
+	return &HTTPRequestBuilder{
+		handler: handler,
+		method:  http.MethodGet,
+		target:  "/",
+		query:   url.Values{},
+		header:  http.Header{},
+	}
+}
+
+// HTTPClientRequest returns a builder for a request to send with client to
+// the server at baseURL, which can include a path prefix. The URL of the
+// request is resolved against it, unless it is absolute. The request is a GET
+// request for "/" until set otherwise.
+//
+//	assert.HTTPClientRequest(http.DefaultClient, server.URL+"/api").
+//		URL("/health").
+//		Timeout(time.Second).
+//		Expect(t).
+//		Success()
+func HTTPClientRequest(client *http.Client, baseURL string) *HTTPRequestBuilder {
+	b := HTTPRequest(nil)
+	if client == nil {
+		client = http.DefaultClient
+	}
+	b.transport = liveClient
+	b.client = client
+	b.baseURL = baseURL
+	return b
+}
+
+// Server makes Expect send the request over a real connection, to an
+// httptest.Server started around the handler for the request and closed
+// after it. Unlike an http.Client, redirects are not followed.
+func (b *HTTPRequestBuilder) Server() *HTTPRequestBuilder {
+	b.transport = liveServer
+	return b
+}
+
+// TLSServer is like Server, but the server uses TLS.
+func (b *HTTPRequestBuilder) TLSServer() *HTTPRequestBuilder {
+	b.transport = liveTLSServer
+	return b
+}
+
+// HTTP2Server is like Server, but the server uses TLS and HTTP/2.
+func (b *HTTPRequestBuilder) HTTP2Server() *HTTPRequestBuilder {
+	b.transport = liveHTTP2Server
+	return b
+}
+
+// Timeout sets the time limit for the round trip of a request sent over a
+// real connection, see Server and HTTPClientRequest. It has no effect on
+// requests sent to the handler in process.
+func (b *HTTPRequestBuilder) Timeout(timeout time.Duration) *HTTPRequestBuilder {
+	b.timeout = timeout
+	return b
+}
+
+// Method sets the method of the request.
+func (b *HTTPRequestBuilder) Method(method string) *HTTPRequestBuilder {
+	b.method = method
+	return b
+}
+
+// URL sets the URL of the request, usually just a path. Query parameters in
+// the URL are kept, and the ones added with Query are appended to them.
+func (b *HTTPRequestBuilder) URL(target string) *HTTPRequestBuilder {
+	b.target = target
+	return b
+}
+
+// Query adds a query parameter to the URL of the request.
+func (b *HTTPRequestBuilder) Query(key, value string) *HTTPRequestBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// Header adds a header to the request.
+func (b *HTTPRequestBuilder) Header(key, value string) *HTTPRequestBuilder {
+	b.header.Add(key, value)
+	return b
+}
+
+// Cookie adds a cookie to the request.
+func (b *HTTPRequestBuilder) Cookie(cookie *http.Cookie) *HTTPRequestBuilder {
+	b.cookies = append(b.cookies, cookie)
+	return b
+}
+
+// setBody sets the body of the request, and its Content-Type header unless it
+// was already set.
+func (b *HTTPRequestBuilder) setBody(body []byte, contentType string) *HTTPRequestBuilder {
+	b.body = body
+	if contentType != "" && b.header.Get("Content-Type") == "" {
+		b.header.Set("Content-Type", contentType)
+	}
+	return b
+}
+
+// Body sets the body of the request.
+func (b *HTTPRequestBuilder) Body(body string) *HTTPRequestBuilder {
+	return b.setBody([]byte(body), "")
+}
+
+// JSONBody sets the body of the request to v encoded as JSON, and sets the
+// Content-Type header to "application/json" unless it was already set.
+func (b *HTTPRequestBuilder) JSONBody(v interface{}) *HTTPRequestBuilder {
+	body, err := json.Marshal(v)
+	if err != nil {
+		b.err = fmt.Errorf("encoding JSON body: %w", err)
+		return b
+	}
+	return b.setBody(body, "application/json")
+}
+
+// FormBody sets the body of the request to the encoded values, and sets the
+// Content-Type header to "application/x-www-form-urlencoded" unless it was
+// already set.
+func (b *HTTPRequestBuilder) FormBody(values url.Values) *HTTPRequestBuilder {
+	return b.setBody([]byte(values.Encode()), "application/x-www-form-urlencoded")
+}
+
+// Build returns the request, or the first error that occurred while it was
+// built.
+func (b *HTTPRequestBuilder) Build() (*http.Request, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	req, err := http.NewRequest(b.method, b.target, bytes.NewReader(b.body))
+	if err != nil {
+		return nil, err
+	}
+	if b.body == nil {
+		req.Body = http.NoBody
+	}
+	if len(b.query) > 0 {
+		query := req.URL.Query()
+		for key, values := range b.query {
+			query[key] = append(query[key], values...)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+	for key, values := range b.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+	for _, cookie := range b.cookies {
+		req.AddCookie(cookie)
+	}
+	return req, nil
+}
+
+// Expect sends the request and returns the response so that it can be
+// checked. By default the request is sent to the handler in process, with an
+// httptest.ResponseRecorder; see Server and HTTPClientRequest to send it over
+// a real connection instead. If the request can't be built or sent, t fails
+// and all the checks of the response fail as well.
+func (b *HTTPRequestBuilder) Expect(t TestingT) *HTTPResponse {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	req, err := b.Build()
+	if err != nil {
+		Fail(t, fmt.Sprintf("Failed to build test request, got error: %s", err))
+		return &HTTPResponse{t: t}
+	}
+	if b.transport != inProcess {
+		return b.roundTrip(t, req)
+	}
+
+	// Set the fields that a server would set for incoming requests
+	req.RequestURI = req.URL.RequestURI()
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	w := httptest.NewRecorder()
+	b.handler.ServeHTTP(w, req)
+	resp := NewHTTPResponse(t, req, w)
+	resp.requestBody = b.body
+	return resp
+}
+
+// roundTrip sends req over a real connection, either to a server started
+// around the handler or with the client of the builder.
+func (b *HTTPRequestBuilder) roundTrip(t TestingT, req *http.Request) *HTTPResponse {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	client, baseURL := b.client, b.baseURL
+	if b.transport != liveClient {
+		server := httptest.NewUnstartedServer(b.handler)
+		switch b.transport {
+		case liveTLSServer:
+			server.StartTLS()
+		case liveHTTP2Server:
+			server.EnableHTTP2 = true
+			server.StartTLS()
+		default:
+			server.Start()
+		}
+		defer server.Close()
+
+		client = server.Client()
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		baseURL = server.URL
+	}
+	if b.timeout > 0 {
+		withTimeout := *client
+		withTimeout.Timeout = b.timeout
+		client = &withTimeout
+	}
+
+	if !req.URL.IsAbs() {
+		base, err := url.Parse(baseURL)
+		if err != nil {
+			Fail(t, fmt.Sprintf("Failed to build test request, got error: %s", err))
+			return &HTTPResponse{t: t}
+		}
+		target := *base
+		target.Path = strings.TrimSuffix(base.Path, "/") + req.URL.Path
+		target.RawPath = strings.TrimSuffix(base.EscapedPath(), "/") + req.URL.EscapedPath()
+		target.RawQuery = req.URL.RawQuery
+		req.URL = &target
+	}
+
+	exchange := &HTTPResponse{t: t, requestBody: b.body, Request: req}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		exchange.Duration = time.Since(start)
+		Fail(t, fmt.Sprintf("Failed to send test request after %v, got error: %s\n\n%s", exchange.Duration, err, exchange.summary()))
+		return &HTTPResponse{t: t}
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	exchange.Duration = time.Since(start)
+	exchange.Response = resp
+	if err != nil {
+		Fail(t, fmt.Sprintf("Failed to read test response body after %v, got error: %s\n\n%s", exchange.Duration, err, exchange.summary()))
+		return &HTTPResponse{t: t}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	exchange.Body = body
+	return exchange
+}
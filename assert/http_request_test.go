@@ -0,0 +1,200 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// httpEcho writes back the parts of the request in the response body.
+func httpEcho(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	cookie, _ := r.Cookie("session")
+	var session string
+	if cookie != nil {
+		session = cookie.Value
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"method":      r.Method,
+		"uri":         r.RequestURI,
+		"host":        r.Host,
+		"contentType": r.Header.Get("Content-Type"),
+		"tags":        r.Header.Values("X-Tag"),
+		"session":     session,
+		"body":        string(body),
+	})
+}
+
+func echoed(t *testing.T, resp *HTTPResponse) map[string]interface{} {
+	var echo map[string]interface{}
+	NoError(t, json.Unmarshal(resp.Body, &echo))
+	return echo
+}
+
+func TestHTTPRequestBuilder(t *testing.T) {
+	t.Parallel()
+
+	resp := HTTPRequest(http.HandlerFunc(httpEcho)).Expect(t)
+	Equal(t, map[string]interface{}{
+		"method":      "GET",
+		"uri":         "/",
+		"host":        "",
+		"contentType": "",
+		"tags":        nil,
+		"session":     "",
+		"body":        "",
+	}, echoed(t, resp))
+
+	resp = HTTPRequest(http.HandlerFunc(httpEcho)).
+		Method("PUT").
+		URL("/users/1?fields=name").
+		Query("fields", "email").
+		Query("verbose", "1").
+		Header("Host", "api.example.com").
+		Header("X-Tag", "a").
+		Header("X-Tag", "b").
+		Cookie(&http.Cookie{Name: "session", Value: "s3cr3t"}).
+		JSONBody(map[string]string{"name": "Ana"}).
+		Expect(t)
+	Equal(t, map[string]interface{}{
+		"method":      "PUT",
+		"uri":         "/users/1?fields=name&fields=email&verbose=1",
+		"host":        "api.example.com",
+		"contentType": "application/json",
+		"tags":        []interface{}{"a", "b"},
+		"session":     "s3cr3t",
+		"body":        `{"name":"Ana"}`,
+	}, echoed(t, resp))
+
+	resp = HTTPRequest(http.HandlerFunc(httpEcho)).
+		Method("POST").
+		FormBody(url.Values{"name": []string{"Ana"}}).
+		Expect(t)
+	Equal(t, "application/x-www-form-urlencoded", echoed(t, resp)["contentType"])
+	Equal(t, "name=Ana", echoed(t, resp)["body"])
+
+	resp = HTTPRequest(http.HandlerFunc(httpEcho)).
+		Method("POST").
+		Header("Content-Type", "text/csv").
+		Body("a,b\n").
+		Expect(t)
+	Equal(t, "text/csv", echoed(t, resp)["contentType"])
+	Equal(t, "a,b\n", echoed(t, resp)["body"])
+}
+
+func TestHTTPRequestBuildError(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	resp := HTTPRequest(http.HandlerFunc(httpOK)).JSONBody(func() {}).Expect(mockT)
+	mockT.checkResultAndErrMsg(t, false, false, "Failed to build test request, got error: encoding JSON body: json: unsupported type: func()\n")
+	Nil(t, resp.Response)
+
+	buildErr := mockT.msg
+	False(t, resp.Success())
+	False(t, resp.BodyContains(""))
+	Equal(t, buildErr, mockT.msg, "checks should not report failures again")
+
+	_, err := HTTPRequest(http.HandlerFunc(httpOK)).Method("bad method").Build()
+	Error(t, err)
+}
+
+// httpProto writes back the protocol and TLS state of the request.
+func httpProto(w http.ResponseWriter, r *http.Request) {
+	_, _ = fmt.Fprintf(w, "%s tls=%t", r.Proto, r.TLS != nil)
+}
+
+func TestHTTPRequestServer(t *testing.T) {
+	t.Parallel()
+
+	resp := HTTPRequest(http.HandlerFunc(httpProto)).Server().Expect(t)
+	True(t, resp.Success())
+	True(t, resp.BodyContains("HTTP/1.1 tls=false"))
+	True(t, resp.Duration > 0)
+
+	resp = HTTPRequest(http.HandlerFunc(httpProto)).TLSServer().Expect(t)
+	True(t, resp.BodyContains("HTTP/1.1 tls=true"))
+
+	resp = HTTPRequest(http.HandlerFunc(httpProto)).HTTP2Server().Expect(t)
+	True(t, resp.BodyContains("HTTP/2.0 tls=true"))
+	Equal(t, 2, resp.Response.ProtoMajor)
+
+	resp = HTTPRequest(http.HandlerFunc(httpEcho)).
+		Server().
+		Method("POST").
+		URL("/users?fields=name").
+		Query("verbose", "1").
+		Cookie(&http.Cookie{Name: "session", Value: "s3cr3t"}).
+		JSONBody(map[string]string{"name": "Ana"}).
+		Expect(t)
+	echo := echoed(t, resp)
+	Equal(t, "POST", echo["method"])
+	Equal(t, "/users?fields=name&verbose=1", echo["uri"])
+	Equal(t, "s3cr3t", echo["session"])
+	Equal(t, `{"name":"Ana"}`, echo["body"])
+
+	// Redirects are reported, not followed
+	True(t, HTTPRequest(http.HandlerFunc(httpLogin)).Server().Method("POST").Expect(t).RedirectsTo("/home"))
+}
+
+func TestHTTPClientRequest(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/hello", httpHelloName)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp := HTTPClientRequest(server.Client(), server.URL+"/api/").URL("/hello").Query("name", "World").Expect(t)
+	True(t, resp.BodyContains("Hello, World!"))
+	Equal(t, server.URL+"/api/hello?name=World", resp.Request.URL.String())
+
+	resp = HTTPClientRequest(server.Client(), "http://ignored.invalid").URL(server.URL + "/api/hello").Expect(t)
+	True(t, resp.Success())
+
+	resp = HTTPClientRequest(nil, server.URL).URL("/api/hello").Expect(t)
+	True(t, resp.Success())
+}
+
+func TestHTTPClientRequestEscapedPath(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(httpEcho))
+	defer server.Close()
+
+	resp := HTTPClientRequest(server.Client(), server.URL+"/files%20v2/").URL("/a%2Fb").Expect(t)
+	Equal(t, server.URL+"/files%20v2/a%2Fb", resp.Request.URL.String())
+	Equal(t, "/files%20v2/a%2Fb", echoed(t, resp)["uri"])
+}
+
+func TestHTTPRequestServerFailures(t *testing.T) {
+	t.Parallel()
+
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	mockT := new(captureTestingT)
+	resp := HTTPRequest(slow).Server().URL("/slow").Timeout(10 * time.Millisecond).Expect(mockT)
+	Nil(t, resp.Response)
+	False(t, resp.Success())
+	Contains(t, mockT.msg, "Failed to send test request after ")
+	Contains(t, mockT.msg, "Client.Timeout exceeded")
+	Contains(t, mockT.msg, "\tGET http://127.0.0.1:")
+	Contains(t, mockT.msg, "Response after ")
+	Contains(t, mockT.msg, "\tnone\n")
+
+	mockT = new(captureTestingT)
+	resp = HTTPRequest(http.HandlerFunc(httpError)).Server().Expect(mockT)
+	False(t, resp.Success())
+	Contains(t, mockT.msg, "Expected HTTP success status code for \"GET http://127.0.0.1:")
+	Contains(t, mockT.msg, "Response after ")
+	Contains(t, mockT.msg, "\tHTTP/1.1 500 Internal Server Error")
+}
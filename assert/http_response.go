@@ -0,0 +1,419 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"time"
+)
+
+// httpSummaryBodyLimit is the number of bytes of each body shown in the
+// summary of an exchange.
+const httpSummaryBodyLimit = 1024
+
+// HTTPResponse is the response to a request sent with an HTTPRequestBuilder,
+// with methods to check it. Each check reports its failure to the TestingT
+// the request was sent with, along with a summary of the request and of the
+// response, and returns whether it was successful.
+type HTTPResponse struct {
+	t           TestingT
+	requestBody []byte
+
+	// Request is the request that was sent.
+	Request *http.Request
+	// Response is the response that was received. Its body has been read
+	// into Body.
+	Response *http.Response
+	// Body is the body of the response.
+	Body []byte
+	// Duration is the time the round trip of a request sent over a real
+	// connection took, from sending the request to reading the whole body of
+	// the response. It is zero for requests sent to the handler in process.
+	Duration time.Duration
+}
+
+// NewHTTPResponse returns the response recorded by w for req, so that it can
+// be checked with the methods of HTTPResponse. Failures are reported to t.
+//
+//	w := httptest.NewRecorder()
+//	handler.ServeHTTP(w, req)
+//	assert.NewHTTPResponse(t, req, w).Success()
+func NewHTTPResponse(t TestingT, req *http.Request, w *httptest.ResponseRecorder) *HTTPResponse {
+	return &HTTPResponse{
+		t:        t,
+		Request:  req,
+		Response: w.Result(),
+		Body:     w.Body.Bytes(),
+	}
+}
+
+// describeRequest returns the method and URL of the request the response is
+// for.
+func (r *HTTPResponse) describeRequest() string {
+	return r.Request.Method + " " + r.Request.URL.String()
+}
+
+// summaryHeaders formats header for the summary of an exchange, one line per
+// value, sorted by key.
+func summaryHeaders(b *strings.Builder, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range header[key] {
+			fmt.Fprintf(b, "\t%s: %s\n", key, value)
+		}
+	}
+}
+
+// summaryBody formats body for the summary of an exchange, up to
+// httpSummaryBodyLimit bytes.
+func summaryBody(b *strings.Builder, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	shown := body
+	if len(shown) > httpSummaryBodyLimit {
+		shown = shown[:httpSummaryBodyLimit]
+	}
+	b.WriteString("\n")
+	for _, line := range strings.Split(strings.TrimSuffix(string(shown), "\n"), "\n") {
+		b.WriteString("\t" + line + "\n")
+	}
+	if len(shown) < len(body) {
+		fmt.Fprintf(b, "\t<%d more bytes>\n", len(body)-len(shown))
+	}
+}
+
+// summary describes the request and the response, for failure messages.
+func (r *HTTPResponse) summary() string {
+	var b strings.Builder
+	b.WriteString("Request:\n")
+	fmt.Fprintf(&b, "\t%s\n", r.describeRequest())
+	summaryHeaders(&b, r.Request.Header)
+	summaryBody(&b, r.requestBody)
+
+	if r.Duration > 0 {
+		fmt.Fprintf(&b, "Response after %v:\n", r.Duration)
+	} else {
+		b.WriteString("Response:\n")
+	}
+	if r.Response == nil {
+		b.WriteString("\tnone")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\t%s %s\n", r.Response.Proto, r.Response.Status)
+	summaryHeaders(&b, r.Response.Header)
+	summaryBody(&b, r.Body)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// fail reports a failure with the summary of the exchange.
+func (r *HTTPResponse) fail(failureMessage string, msgAndArgs []interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Fail(r.t, strings.TrimRight(failureMessage, "\n")+"\n\n"+r.summary(), msgAndArgs...)
+}
+
+// checkStatus asserts that isExpected returns true for the status code of the
+// response, which is described by kind in the failure message.
+func (r *HTTPResponse) checkStatus(isExpected func(code int) bool, kind string, msgAndArgs []interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	if !isExpected(r.Response.StatusCode) {
+		return r.fail(fmt.Sprintf("Expected HTTP %s for %q but received %d", kind, r.describeRequest(), r.Response.StatusCode), msgAndArgs)
+	}
+	return true
+}
+
+// Success asserts that the response has a success status code.
+//
+//	assert.HTTPRequest(handler).Expect(t).Success()
+func (r *HTTPResponse) Success(msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	return r.checkStatus(func(code int) bool {
+		return code >= http.StatusOK && code <= http.StatusPartialContent
+	}, "success status code", msgAndArgs)
+}
+
+// Redirect asserts that the response has a redirect status code.
+//
+//	assert.HTTPRequest(handler).URL("/old").Expect(t).Redirect()
+func (r *HTTPResponse) Redirect(msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	return r.checkStatus(isRedirectCode, "redirect status code", msgAndArgs)
+}
+
+// isRedirectCode returns true for the status codes that Redirect accepts.
+func isRedirectCode(code int) bool {
+	return code >= http.StatusMultipleChoices && code <= http.StatusTemporaryRedirect
+}
+
+// Error asserts that the response has an error status code.
+//
+//	assert.HTTPRequest(handler).URL("/missing").Expect(t).Error()
+func (r *HTTPResponse) Error(msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	return r.checkStatus(func(code int) bool {
+		return code >= http.StatusBadRequest
+	}, "error status code", msgAndArgs)
+}
+
+// StatusCode asserts that the response has the specified status code.
+//
+//	assert.HTTPRequest(handler).Method("DELETE").Expect(t).StatusCode(http.StatusNoContent)
+func (r *HTTPResponse) StatusCode(statuscode int, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	return r.checkStatus(func(code int) bool {
+		return code == statuscode
+	}, fmt.Sprintf("status code %d", statuscode), msgAndArgs)
+}
+
+// BodyContains asserts that the body of the response contains a string.
+//
+//	assert.HTTPRequest(handler).Expect(t).BodyContains("I'm Feeling Lucky")
+func (r *HTTPResponse) BodyContains(str interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	if !strings.Contains(string(r.Body), fmt.Sprint(str)) {
+		return r.fail(fmt.Sprintf("Expected response body for %q to contain %q", r.describeRequest(), str), msgAndArgs)
+	}
+	return true
+}
+
+// BodyNotContains asserts that the body of the response does not contain a
+// string.
+//
+//	assert.HTTPRequest(handler).Expect(t).BodyNotContains("Internal error")
+func (r *HTTPResponse) BodyNotContains(str interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	if strings.Contains(string(r.Body), fmt.Sprint(str)) {
+		return r.fail(fmt.Sprintf("Expected response body for %q to NOT contain %q", r.describeRequest(), str), msgAndArgs)
+	}
+	return true
+}
+
+// Header asserts that the response has the header key, and that its first
+// value is equal to value.
+//
+//	assert.HTTPRequest(handler).Expect(t).Header("Cache-Control", "no-store")
+func (r *HTTPResponse) Header(key, value string, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	values := r.Response.Header.Values(key)
+	if len(values) == 0 {
+		return r.fail(fmt.Sprintf("Expected response header %q to be %q but it is absent", key, value), msgAndArgs)
+	}
+	if values[0] != value {
+		return r.fail(fmt.Sprintf("Expected response header %q to be %q but found %q", key, value, values[0]), msgAndArgs)
+	}
+	return true
+}
+
+// HeaderRegexp asserts that the response has the header key, and that its
+// first value matches the regexp rx.
+//
+//	assert.HTTPRequest(handler).Expect(t).HeaderRegexp("ETag", `^"[0-9a-f]{32}"$`)
+func (r *HTTPResponse) HeaderRegexp(key string, rx interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	re := compileRegexp(rx)
+	values := r.Response.Header.Values(key)
+	if len(values) == 0 {
+		return r.fail(fmt.Sprintf("Expected response header %q to match %q but it is absent", key, re), msgAndArgs)
+	}
+	if !re.MatchString(values[0]) {
+		return r.fail(fmt.Sprintf("Expected response header %q to match %q but found %q", key, re, values[0]), msgAndArgs)
+	}
+	return true
+}
+
+// HeaderPresent asserts that the response has the header key, whatever its
+// value.
+//
+//	assert.HTTPRequest(handler).Expect(t).HeaderPresent("X-Request-Id")
+func (r *HTTPResponse) HeaderPresent(key string, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	if len(r.Response.Header.Values(key)) == 0 {
+		return r.fail(fmt.Sprintf("Expected response header %q to be present", key), msgAndArgs)
+	}
+	return true
+}
+
+// HeaderAbsent asserts that the response does not have the header key.
+//
+//	assert.HTTPRequest(handler).Expect(t).HeaderAbsent("Server")
+func (r *HTTPResponse) HeaderAbsent(key string, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	if values := r.Response.Header.Values(key); len(values) > 0 {
+		return r.fail(fmt.Sprintf("Expected response header %q to be absent but found %q", key, values), msgAndArgs)
+	}
+	return true
+}
+
+// ContentType asserts that the media type of the Content-Type header of the
+// response is mediaType. Parameters such as charset are ignored.
+//
+//	assert.HTTPRequest(handler).Expect(t).ContentType("application/json")
+func (r *HTTPResponse) ContentType(mediaType string, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	contentType := r.Response.Header.Get("Content-Type")
+	actual, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return r.fail(fmt.Sprintf("Expected response Content-Type to be %q but found %q", mediaType, contentType), msgAndArgs)
+	}
+	if !strings.EqualFold(actual, mediaType) {
+		return r.fail(fmt.Sprintf("Expected response Content-Type to be %q but found %q", mediaType, actual), msgAndArgs)
+	}
+	return true
+}
+
+// Cookie asserts that the response sets the cookie expected.Name, and that the
+// attributes of expected that are not zero (Value, Path, Domain, Expires,
+// MaxAge, Secure, HttpOnly and SameSite) match those of the cookie.
+//
+//	assert.HTTPRequest(handler).Expect(t).Cookie(&http.Cookie{Name: "session", HttpOnly: true, Secure: true})
+func (r *HTTPResponse) Cookie(expected *http.Cookie, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	var cookie *http.Cookie
+	for _, c := range r.Response.Cookies() {
+		if c.Name == expected.Name {
+			cookie = c
+		}
+	}
+	if cookie == nil {
+		return r.fail(fmt.Sprintf("Expected response to set cookie %q", expected.Name), msgAndArgs)
+	}
+
+	var mismatches []string
+	check := func(attribute string, isSet bool, expected, actual interface{}) {
+		if isSet && !ObjectsAreEqual(expected, actual) {
+			mismatches = append(mismatches, fmt.Sprintf("\t%s: expected %#v, actual %#v", attribute, expected, actual))
+		}
+	}
+	check("Value", expected.Value != "", expected.Value, cookie.Value)
+	check("Path", expected.Path != "", expected.Path, cookie.Path)
+	check("Domain", expected.Domain != "", expected.Domain, cookie.Domain)
+	check("Expires", !expected.Expires.IsZero(), expected.Expires.UTC().Format(http.TimeFormat), cookie.Expires.UTC().Format(http.TimeFormat))
+	check("MaxAge", expected.MaxAge != 0, expected.MaxAge, cookie.MaxAge)
+	check("Secure", expected.Secure, expected.Secure, cookie.Secure)
+	check("HttpOnly", expected.HttpOnly, expected.HttpOnly, cookie.HttpOnly)
+	check("SameSite", expected.SameSite != 0, expected.SameSite, cookie.SameSite)
+	if len(mismatches) > 0 {
+		return r.fail(fmt.Sprintf("Cookie %q does not have the expected attributes:\n%s", expected.Name, strings.Join(mismatches, "\n")), msgAndArgs)
+	}
+	return true
+}
+
+// RedirectsTo asserts that the response has a redirect status code, and that
+// its Location header is location.
+//
+//	assert.HTTPRequest(handler).URL("/old").Expect(t).RedirectsTo("/new")
+func (r *HTTPResponse) RedirectsTo(location string, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if !r.checkStatus(isRedirectCode, "redirect status code", msgAndArgs) {
+		return false
+	}
+
+	if actual := r.Response.Header.Get("Location"); actual != location {
+		return r.fail(fmt.Sprintf("Expected redirect for %q to %q but found %q", r.describeRequest(), location, actual), msgAndArgs)
+	}
+	return true
+}
+
+// JSONEq asserts that the body of the response is JSON equivalent to the
+// expected JSON string, as JSONEq does.
+//
+//	assert.HTTPRequest(handler).Expect(t).JSONEq(`{"id": 1, "name": "Ana"}`)
+func (r *HTTPResponse) JSONEq(expected string, msgAndArgs ...interface{}) bool {
+	if h, ok := r.t.(tHelper); ok {
+		h.Helper()
+	}
+	if r.Response == nil {
+		return false
+	}
+
+	var expectedJSONAsInterface, actualJSONAsInterface interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedJSONAsInterface); err != nil {
+		return r.fail(fmt.Sprintf("Expected value ('%s') is not valid json.\nJSON parsing error: '%s'", expected, err.Error()), msgAndArgs)
+	}
+	if err := json.Unmarshal(r.Body, &actualJSONAsInterface); err != nil {
+		return r.fail(fmt.Sprintf("Response body needs to be valid json.\nJSON parsing error: '%s'", err.Error()), msgAndArgs)
+	}
+
+	if !ObjectsAreEqual(expectedJSONAsInterface, actualJSONAsInterface) {
+		diff := diff(expectedJSONAsInterface, actualJSONAsInterface)
+		expected, actual := formatUnequalValues(expectedJSONAsInterface, actualJSONAsInterface)
+		return r.fail(fmt.Sprintf("Response body not equal: \n"+
+			"expected: %s\n"+
+			"actual  : %s%s", expected, actual, diff), msgAndArgs)
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package assert
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPResponseStatus(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(testing.T)
+	True(t, HTTPRequest(http.HandlerFunc(httpOK)).Expect(mockT).Success())
+	False(t, HTTPRequest(http.HandlerFunc(httpRedirect)).Expect(mockT).Success())
+	True(t, HTTPRequest(http.HandlerFunc(httpRedirect)).Expect(mockT).Redirect())
+	False(t, HTTPRequest(http.HandlerFunc(httpOK)).Expect(mockT).Redirect())
+	True(t, HTTPRequest(http.HandlerFunc(httpError)).Expect(mockT).Error())
+	False(t, HTTPRequest(http.HandlerFunc(httpOK)).Expect(mockT).Error())
+	True(t, HTTPRequest(http.HandlerFunc(httpStatusCode)).Expect(mockT).StatusCode(http.StatusSwitchingProtocols))
+	False(t, HTTPRequest(http.HandlerFunc(httpStatusCode)).Expect(mockT).StatusCode(http.StatusOK))
+
+	captureT := new(captureTestingT)
+	res := HTTPRequest(http.HandlerFunc(httpError)).URL("/a?b=c").Expect(captureT).Success()
+	captureT.checkResultAndErrMsg(t, false, res, "Expected HTTP success status code for \"GET /a?b=c\" but received 500\n\n"+
+		"Request:\n"+
+		"\tGET /a?b=c\n"+
+		"Response:\n"+
+		"\tHTTP/1.1 500 Internal Server Error\n")
+
+	res = HTTPRequest(http.HandlerFunc(httpError)).Method("DELETE").Expect(captureT).StatusCode(http.StatusNoContent)
+	captureT.checkResultAndErrMsg(t, false, res, "Expected HTTP status code 204 for \"DELETE /\" but received 500\n\n"+
+		"Request:\n"+
+		"\tDELETE /\n"+
+		"Response:\n"+
+		"\tHTTP/1.1 500 Internal Server Error\n")
+}
+
+func TestHTTPResponseBody(t *testing.T) {
+	t.Parallel()
+
+	resp := HTTPRequest(http.HandlerFunc(httpHelloName)).Query("name", "World").Expect(t)
+	True(t, resp.BodyContains("Hello, World!"))
+	True(t, resp.BodyNotContains("world"))
+
+	mockT := new(captureTestingT)
+	resp = HTTPRequest(http.HandlerFunc(httpHelloName)).Query("name", "World").Expect(mockT)
+	summary := "Request:\n" +
+		"\tGET /?name=World\n" +
+		"Response:\n" +
+		"\tHTTP/1.1 200 OK\n" +
+		"\tContent-Type: text/plain; charset=utf-8\n" +
+		"\n" +
+		"\tHello, World!\n"
+	res := resp.BodyContains("world")
+	mockT.checkResultAndErrMsg(t, false, res, "Expected response body for \"GET /?name=World\" to contain \"world\"\n\n"+summary)
+	res = resp.BodyNotContains("World")
+	mockT.checkResultAndErrMsg(t, false, res, "Expected response body for \"GET /?name=World\" to NOT contain \"World\"\n\n"+summary)
+}
+
+func TestNewHTTPResponse(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest("GET", "/?name=Go", nil)
+	w := httptest.NewRecorder()
+	httpHelloName(w, req)
+
+	resp := NewHTTPResponse(t, req, w)
+	True(t, resp.Success())
+	True(t, resp.BodyContains("Hello, Go!"))
+}
+
+func httpLogin(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "session",
+		Value:    "s3cr3t",
+		Path:     "/",
+		MaxAge:   3600,
+		Expires:  time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.Header().Set("X-Request-Id", "req-42")
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, "/home", http.StatusSeeOther)
+}
+
+func httpUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write([]byte(`{"id": 1, "name": "Ana", "roles": ["admin"]}`))
+}
+
+func TestHTTPResponseHeaders(t *testing.T) {
+	t.Parallel()
+
+	resp := HTTPRequest(http.HandlerFunc(httpLogin)).Method("POST").Expect(t)
+	True(t, resp.Header("Cache-Control", "no-store"))
+	True(t, resp.HeaderRegexp("X-Request-Id", `^req-\d+$`))
+	True(t, resp.HeaderPresent("X-Request-Id"))
+	True(t, resp.HeaderAbsent("Server"))
+
+	mockT := new(captureTestingT)
+	resp = HTTPRequest(http.HandlerFunc(httpLogin)).Method("POST").Expect(mockT)
+
+	False(t, resp.Header("Cache-Control", "no-cache"))
+	Contains(t, mockT.msg, `Expected response header "Cache-Control" to be "no-cache" but found "no-store"`)
+	False(t, resp.Header("ETag", "abc"))
+	Contains(t, mockT.msg, `Expected response header "ETag" to be "abc" but it is absent`)
+	False(t, resp.HeaderRegexp("X-Request-Id", `^id-`))
+	Contains(t, mockT.msg, `Expected response header "X-Request-Id" to match "^id-" but found "req-42"`)
+	False(t, resp.HeaderPresent("ETag"))
+	Contains(t, mockT.msg, `Expected response header "ETag" to be present`)
+	False(t, resp.HeaderAbsent("X-Request-Id"))
+	Contains(t, mockT.msg, `Expected response header "X-Request-Id" to be absent but found ["req-42"]`)
+}
+
+func TestHTTPResponseContentType(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	resp := HTTPRequest(http.HandlerFunc(httpUser)).Expect(mockT)
+	True(t, resp.ContentType("application/json"))
+	True(t, resp.ContentType("Application/JSON"))
+
+	False(t, resp.ContentType("text/html"))
+	Contains(t, mockT.msg, `Expected response Content-Type to be "text/html" but found "application/json"`)
+
+	resp = HTTPRequest(http.HandlerFunc(httpOK)).Expect(mockT)
+	False(t, resp.ContentType("text/html"))
+	Contains(t, mockT.msg, `Expected response Content-Type to be "text/html" but found ""`)
+}
+
+func TestHTTPResponseCookie(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	resp := HTTPRequest(http.HandlerFunc(httpLogin)).Method("POST").Expect(mockT)
+	True(t, resp.Cookie(&http.Cookie{Name: "session"}))
+	True(t, resp.Cookie(&http.Cookie{
+		Name:     "session",
+		Value:    "s3cr3t",
+		Path:     "/",
+		MaxAge:   3600,
+		Expires:  time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}))
+
+	False(t, resp.Cookie(&http.Cookie{Name: "remember"}))
+	Contains(t, mockT.msg, `Expected response to set cookie "remember"`)
+
+	False(t, resp.Cookie(&http.Cookie{Name: "session", Value: "other", Path: "/admin", SameSite: http.SameSiteStrictMode}))
+	Contains(t, mockT.msg, "Cookie \"session\" does not have the expected attributes:\n")
+	Contains(t, mockT.msg, `Value: expected "other", actual "s3cr3t"`)
+	Contains(t, mockT.msg, `Path: expected "/admin", actual "/"`)
+	Contains(t, mockT.msg, `SameSite: expected 3, actual 2`)
+	NotContains(t, mockT.msg, "MaxAge:")
+}
+
+func TestHTTPResponseRedirectsTo(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	True(t, HTTPRequest(http.HandlerFunc(httpLogin)).Method("POST").Expect(mockT).RedirectsTo("/home"))
+
+	res := HTTPRequest(http.HandlerFunc(httpLogin)).Method("POST").Expect(mockT).RedirectsTo("/dashboard")
+	False(t, res)
+	Contains(t, mockT.msg, `Expected redirect for "POST /" to "/dashboard" but found "/home"`)
+
+	res = HTTPRequest(http.HandlerFunc(httpOK)).Expect(mockT).RedirectsTo("/home")
+	False(t, res)
+	Contains(t, mockT.msg, `Expected HTTP redirect status code for "GET /" but received 200`)
+}
+
+func TestHTTPResponseJSONEq(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	resp := HTTPRequest(http.HandlerFunc(httpUser)).Expect(mockT)
+	True(t, resp.JSONEq(`{"roles": ["admin"], "name": "Ana", "id": 1}`))
+
+	False(t, resp.JSONEq(`{"id": 1, "name": "Bob", "roles": ["admin"]}`))
+	Contains(t, mockT.msg, "Response body not equal")
+	Contains(t, mockT.msg, "- (string) (len=4) \"name\": (string) (len=3) \"Bob\",")
+	Contains(t, mockT.msg, "+ (string) (len=4) \"name\": (string) (len=3) \"Ana\",")
+
+	False(t, resp.JSONEq(`{`))
+	Contains(t, mockT.msg, "Expected value ('{') is not valid json.")
+
+	False(t, HTTPRequest(http.HandlerFunc(httpHelloName)).Expect(mockT).JSONEq(`{}`))
+	Contains(t, mockT.msg, "Response body needs to be valid json.")
+}
+
+func TestHTTPResponseSummary(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	resp := HTTPRequest(http.HandlerFunc(httpUser)).
+		Method("POST").
+		URL("/users").
+		Header("Authorization", "Bearer token").
+		JSONBody(map[string]string{"name": "Ana"}).
+		Expect(mockT)
+	res := resp.StatusCode(http.StatusCreated)
+	mockT.checkResultAndErrMsg(t, false, res, "Expected HTTP status code 201 for \"POST /users\" but received 200\n\n"+
+		"Request:\n"+
+		"\tPOST /users\n"+
+		"\tAuthorization: Bearer token\n"+
+		"\tContent-Type: application/json\n"+
+		"\n"+
+		"\t{\"name\":\"Ana\"}\n"+
+		"Response:\n"+
+		"\tHTTP/1.1 200 OK\n"+
+		"\tContent-Type: application/json; charset=utf-8\n"+
+		"\n"+
+		"\t{\"id\": 1, \"name\": \"Ana\", \"roles\": [\"admin\"]}\n")
+
+	large := strings.Repeat("x", httpSummaryBodyLimit+10)
+	resp = HTTPRequest(http.HandlerFunc(httpOK)).Body(large).Expect(mockT)
+	False(t, resp.StatusCode(http.StatusCreated))
+	Contains(t, mockT.msg, "\t<10 more bytes>")
+}
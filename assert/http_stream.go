@@ -0,0 +1,411 @@
+package assert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// httpStreamTimeout is how long the checks of an HTTPStream wait for the
+// handler by default.
+const httpStreamTimeout = time.Second
+
+// StreamRecorder is an http.ResponseWriter and http.Flusher for testing
+// handlers that stream their response. Like a server, it keeps what the
+// handler writes in a buffer until the handler calls Flush or returns, so
+// that only the flushed part of the response can be read.
+type StreamRecorder struct {
+	mutex   sync.Mutex
+	code    int
+	header  http.Header
+	written http.Header
+	pending []byte
+	flushed []byte
+	flushes int
+	closed  bool
+	changed chan struct{}
+}
+
+// NewStreamRecorder returns an initialized StreamRecorder.
+func NewStreamRecorder() *StreamRecorder {
+	return &StreamRecorder{
+		header:  http.Header{},
+		changed: make(chan struct{}),
+	}
+}
+
+// Header returns the header map that the handler can change until it writes
+// the status code or the body.
+func (r *StreamRecorder) Header() http.Header {
+	return r.header
+}
+
+// WriteHeader sends the status code. Only the first call has an effect.
+func (r *StreamRecorder) WriteHeader(code int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.writeHeader(code)
+}
+
+func (r *StreamRecorder) writeHeader(code int) {
+	if r.code != 0 {
+		return
+	}
+	r.code = code
+	r.written = r.header.Clone()
+}
+
+// Write adds p to the buffered part of the response.
+func (r *StreamRecorder) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.writeHeader(http.StatusOK)
+	r.pending = append(r.pending, p...)
+	return len(p), nil
+}
+
+// Flush makes the buffered part of the response readable.
+func (r *StreamRecorder) Flush() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.writeHeader(http.StatusOK)
+	r.flushes++
+	r.flush()
+}
+
+func (r *StreamRecorder) flush() {
+	r.flushed = append(r.flushed, r.pending...)
+	r.pending = nil
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// Flushes returns the number of times Flush was called.
+func (r *StreamRecorder) Flushes() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.flushes
+}
+
+// Code returns the status code sent by the handler, or 0 if it has not sent
+// one yet.
+func (r *StreamRecorder) Code() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.code
+}
+
+// HeaderMap returns the headers sent with the status code, or nil if it has
+// not been sent yet.
+func (r *StreamRecorder) HeaderMap() http.Header {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.written.Clone()
+}
+
+// Flushed returns the part of the response that was flushed.
+func (r *StreamRecorder) Flushed() []byte {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]byte(nil), r.flushed...)
+}
+
+// close is called when the handler returns, which flushes the response.
+func (r *StreamRecorder) close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.writeHeader(http.StatusOK)
+	r.closed = true
+	r.flush()
+}
+
+// state returns the flushed part of the response, the number of buffered
+// bytes, whether the handler returned, and a channel closed on the next
+// change.
+func (r *StreamRecorder) state() ([]byte, int, bool, <-chan struct{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.flushed, len(r.pending), r.closed, r.changed
+}
+
+// SSEEvent is a server-sent event, as defined by the HTML standard.
+type SSEEvent struct {
+	// Event is the type of the event, empty for the default "message" type.
+	Event string
+	// Data is the data of the event, with the lines of multi-line data joined
+	// by "\n".
+	Data string
+	// ID is the id of the event.
+	ID string
+}
+
+// parseSSEEvents returns the complete events at the start of data, and the
+// number of bytes they take.
+func parseSSEEvents(data []byte) ([]SSEEvent, int) {
+	var events []SSEEvent
+	var event SSEEvent
+	var lines []string
+	hasData := false
+	offset, consumed := 0, 0
+	for {
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end < 0 {
+			return events, consumed
+		}
+		line := strings.TrimSuffix(string(data[offset:offset+end]), "\r")
+		offset += end + 1
+
+		if line == "" {
+			if hasData {
+				event.Data = strings.Join(lines, "\n")
+				events = append(events, event)
+			}
+			event, lines, hasData = SSEEvent{}, nil, false
+			consumed = offset
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			lines = append(lines, value)
+			hasData = true
+		case "id":
+			event.ID = value
+		}
+	}
+}
+
+// HTTPStream is the response of a handler that is still running, to check
+// what it streams. It is returned by HTTPRequestBuilder.Stream.
+type HTTPStream struct {
+	t       TestingT
+	timeout time.Duration
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	// Request is the request sent to the handler.
+	Request *http.Request
+	// Recorder is the response writer given to the handler.
+	Recorder *StreamRecorder
+
+	events      []SSEEvent
+	eventOffset int
+	nextEvent   int
+	lineOffset  int
+}
+
+// Stream calls the handler in a new goroutine with a StreamRecorder, and
+// returns the stream of its response so that it can be checked as it is
+// flushed. Close the stream to cancel the context of the request and wait for
+// the handler to return. If the request can't be built, or is not for a
+// handler in process, t fails and all the checks of the stream fail as well.
+//
+//	stream := assert.HTTPRequest(events).URL("/events").Stream(t)
+//	defer stream.Close()
+//	stream.EventMatches(0, `"status":"started"`)
+func (b *HTTPRequestBuilder) Stream(t TestingT) *HTTPStream {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if b.transport != inProcess {
+		Fail(t, "Streams can only be read from a handler called in process")
+		return &HTTPStream{t: t}
+	}
+	req, err := b.Build()
+	if err != nil {
+		Fail(t, fmt.Sprintf("Failed to build test request, got error: %s", err))
+		return &HTTPStream{t: t}
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	req = req.WithContext(ctx)
+	req.RequestURI = req.URL.RequestURI()
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	s := &HTTPStream{
+		t:        t,
+		timeout:  httpStreamTimeout,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		Request:  req,
+		Recorder: NewStreamRecorder(),
+	}
+	go func() {
+		defer close(s.done)
+		defer s.Recorder.close()
+		b.handler.ServeHTTP(s.Recorder, req)
+	}()
+	return s
+}
+
+// Timeout sets how long EventMatches and Close wait for the handler. It is
+// one second by default.
+func (s *HTTPStream) Timeout(timeout time.Duration) *HTTPStream {
+	s.timeout = timeout
+	return s
+}
+
+// wait waits for ready to report true for the flushed part of the response.
+// It returns the number of bytes buffered and whether the handler returned,
+// and false if the handler returns or the timeout expires first.
+func (s *HTTPStream) wait(timeout time.Duration, ready func(flushed []byte) bool) (int, bool, bool) {
+	timer := clockFor(s.t).NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		flushed, pending, closed, changed := s.Recorder.state()
+		if ready(flushed) {
+			return pending, closed, true
+		}
+		if closed {
+			return pending, closed, false
+		}
+		select {
+		case <-changed:
+		case <-timer.C():
+			_, pending, closed, _ = s.Recorder.state()
+			return pending, closed, false
+		}
+	}
+}
+
+// waitFailure describes why nothing came out of a wait.
+func waitFailure(what string, timeout time.Duration, pending int, closed bool) string {
+	if closed {
+		return fmt.Sprintf("Expected %s, but the handler returned", what)
+	}
+	msg := fmt.Sprintf("Expected %s within %v, but none was flushed", what, timeout)
+	if pending > 0 {
+		msg += fmt.Sprintf(" (%d bytes were written without calling Flush)", pending)
+	}
+	return msg
+}
+
+// parsedEvents waits until more than n events were flushed.
+func (s *HTTPStream) parsedEvents(n int, timeout time.Duration) (int, bool, bool) {
+	return s.wait(timeout, func(flushed []byte) bool {
+		events, size := parseSSEEvents(flushed[s.eventOffset:])
+		s.events = append(s.events, events...)
+		s.eventOffset += size
+		return len(s.events) > n
+	})
+}
+
+// NextEvent asserts that the handler flushes a server-sent event within
+// timeout, and returns it. Events returned by NextEvent are not returned
+// again.
+//
+//	event, ok := stream.NextEvent(time.Second)
+func (s *HTTPStream) NextEvent(timeout time.Duration, msgAndArgs ...interface{}) (SSEEvent, bool) {
+	if h, ok := s.t.(tHelper); ok {
+		h.Helper()
+	}
+	if s.Recorder == nil {
+		return SSEEvent{}, false
+	}
+
+	pending, closed, ok := s.parsedEvents(s.nextEvent, timeout)
+	if !ok {
+		return SSEEvent{}, Fail(s.t, waitFailure(fmt.Sprintf("server-sent event %d", s.nextEvent), timeout, pending, closed), msgAndArgs...)
+	}
+	s.nextEvent++
+	return s.events[s.nextEvent-1], true
+}
+
+// EventMatches asserts that the n-th server-sent event of the stream,
+// counting from 0, is flushed and that its data matches the regexp rx.
+//
+//	stream.EventMatches(2, `^\{"progress":100\b`)
+func (s *HTTPStream) EventMatches(n int, rx interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := s.t.(tHelper); ok {
+		h.Helper()
+	}
+	if s.Recorder == nil {
+		return false
+	}
+
+	pending, closed, ok := s.parsedEvents(n, s.timeout)
+	if !ok {
+		return Fail(s.t, waitFailure(fmt.Sprintf("server-sent event %d", n), s.timeout, pending, closed), msgAndArgs...)
+	}
+	if data := s.events[n].Data; !matchRegexp(rx, data) {
+		return Fail(s.t, fmt.Sprintf("Expected data of server-sent event %d to match %q, but found %q", n, compileRegexp(rx), data), msgAndArgs...)
+	}
+	return true
+}
+
+// NextLine asserts that the handler flushes a line within timeout, and returns
+// it without its line ending. This suits line delimited formats like NDJSON.
+// Lines returned by NextLine are not returned again.
+//
+//	line, ok := stream.NextLine(time.Second)
+func (s *HTTPStream) NextLine(timeout time.Duration, msgAndArgs ...interface{}) (string, bool) {
+	if h, ok := s.t.(tHelper); ok {
+		h.Helper()
+	}
+	if s.Recorder == nil {
+		return "", false
+	}
+
+	var line string
+	pending, closed, ok := s.wait(timeout, func(flushed []byte) bool {
+		end := bytes.IndexByte(flushed[s.lineOffset:], '\n')
+		if end < 0 {
+			return false
+		}
+		line = strings.TrimSuffix(string(flushed[s.lineOffset:s.lineOffset+end]), "\r")
+		s.lineOffset += end + 1
+		return true
+	})
+	if !ok {
+		return "", Fail(s.t, waitFailure("a line", timeout, pending, closed), msgAndArgs...)
+	}
+	return line, true
+}
+
+// Flushed asserts that the handler called Flush at least once.
+func (s *HTTPStream) Flushed(msgAndArgs ...interface{}) bool {
+	if h, ok := s.t.(tHelper); ok {
+		h.Helper()
+	}
+	if s.Recorder == nil {
+		return false
+	}
+
+	if s.Recorder.Flushes() == 0 {
+		return Fail(s.t, "Expected the handler to call Flush, but it did not", msgAndArgs...)
+	}
+	return true
+}
+
+// Close cancels the context of the request, and asserts that the handler
+// returns within the timeout of the stream.
+func (s *HTTPStream) Close(msgAndArgs ...interface{}) bool {
+	if h, ok := s.t.(tHelper); ok {
+		h.Helper()
+	}
+	if s.Recorder == nil {
+		return false
+	}
+
+	s.cancel()
+	timer := clockFor(s.t).NewTimer(s.timeout)
+	defer timer.Stop()
+	select {
+	case <-s.done:
+		return true
+	case <-timer.C():
+		return Fail(s.t, fmt.Sprintf("Expected the handler to return within %v after its request was canceled", s.timeout), msgAndArgs...)
+	}
+}
@@ -0,0 +1,114 @@
+package assert
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// httpEvents streams a server-sent event for each value of the "n" query
+// parameter, flushed one by one, then waits for the request to be canceled.
+func httpEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for i, n := range r.URL.Query()["n"] {
+		fmt.Fprintf(w, ": keep-alive\n\nid: %d\nevent: tick\ndata: {\"n\":%s}\ndata: end\n\n", i, n)
+		w.(http.Flusher).Flush()
+	}
+	<-r.Context().Done()
+}
+
+// httpNDJSON streams lines without flushing them.
+func httpNDJSON(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, "{\"a\":1}\r\n{\"b\":2}\n")
+	<-r.Context().Done()
+}
+
+func TestParseSSEEvents(t *testing.T) {
+	t.Parallel()
+
+	events, n := parseSSEEvents([]byte("data: a\n\n:comment\n\nevent: x\ndata\ndata:b\r\n\r\ndata: partial\n"))
+	Equal(t, []SSEEvent{{Data: "a"}, {Event: "x", Data: "\nb"}}, events)
+	Equal(t, 43, n)
+}
+
+func TestHTTPStreamEvents(t *testing.T) {
+	t.Parallel()
+
+	stream := HTTPRequest(http.HandlerFunc(httpEvents)).URL("/events?n=1&n=2&n=3").Stream(t)
+	event, ok := stream.NextEvent(time.Second)
+	True(t, ok)
+	Equal(t, SSEEvent{Event: "tick", Data: "{\"n\":1}\nend", ID: "0"}, event)
+	True(t, stream.EventMatches(2, `"n":3`))
+	event, _ = stream.NextEvent(time.Second)
+	Equal(t, "1", event.ID)
+	True(t, stream.Flushed())
+	Equal(t, http.StatusOK, stream.Recorder.Code())
+	Equal(t, "text/event-stream", stream.Recorder.HeaderMap().Get("Content-Type"))
+	True(t, stream.Close())
+
+	mockT := new(captureTestingT)
+	stream = HTTPRequest(http.HandlerFunc(httpEvents)).URL("/events?n=1").Stream(mockT).Timeout(10 * time.Millisecond)
+	defer stream.Close()
+	res := stream.EventMatches(0, `"n":2`)
+	mockT.checkResultAndErrMsg(t, false, res, "Expected data of server-sent event 0 to match \"\\\"n\\\":2\", but found \"{\\\"n\\\":1}\\nend\"\n")
+	res = stream.EventMatches(1, `.`)
+	mockT.checkResultAndErrMsg(t, false, res, "Expected server-sent event 1 within 10ms, but none was flushed\n")
+}
+
+func TestHTTPStreamLines(t *testing.T) {
+	t.Parallel()
+
+	mockT := new(captureTestingT)
+	stream := HTTPRequest(http.HandlerFunc(httpNDJSON)).Stream(mockT)
+	_, ok := stream.NextLine(10 * time.Millisecond)
+	mockT.checkResultAndErrMsg(t, false, ok, "Expected a line within 10ms, but none was flushed (17 bytes were written without calling Flush)\n")
+	res := stream.Flushed()
+	mockT.checkResultAndErrMsg(t, false, res, "Expected the handler to call Flush, but it did not\n")
+
+	// Returning flushes the response
+	True(t, stream.Close())
+	line, _ := stream.NextLine(time.Second)
+	Equal(t, `{"a":1}`, line)
+	line, _ = stream.NextLine(time.Second)
+	Equal(t, `{"b":2}`, line)
+	_, ok = stream.NextLine(time.Second)
+	mockT.checkResultAndErrMsg(t, false, ok, "Expected a line, but the handler returned\n")
+}
+
+func TestHTTPStreamFailures(t *testing.T) {
+	t.Parallel()
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	mockT := new(captureTestingT)
+	stream := HTTPRequest(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-stuck })).Stream(mockT)
+	res := stream.Timeout(10 * time.Millisecond).Close()
+	mockT.checkResultAndErrMsg(t, false, res, "Expected the handler to return within 10ms after its request was canceled\n")
+
+	stream = HTTPRequest(http.HandlerFunc(httpOK)).Server().Stream(mockT)
+	mockT.checkResultAndErrMsg(t, false, false, "Streams can only be read from a handler called in process\n")
+	buildErr := mockT.msg
+	False(t, stream.EventMatches(0, "."))
+	False(t, stream.Flushed())
+	False(t, stream.Close())
+	Equal(t, buildErr, mockT.msg, "checks should not report failures again")
+}
+
+func TestStreamRecorder(t *testing.T) {
+	t.Parallel()
+
+	w := NewStreamRecorder()
+	w.Header().Set("X-Before", "1")
+	_, _ = w.Write([]byte("hello "))
+	w.Header().Set("X-After", "1")
+	w.WriteHeader(http.StatusTeapot)
+	Equal(t, http.StatusOK, w.Code())
+	Equal(t, http.Header{"X-Before": []string{"1"}}, w.HeaderMap())
+	Empty(t, w.Flushed())
+
+	w.Flush()
+	_, _ = w.Write([]byte("world"))
+	Equal(t, "hello ", string(w.Flushed()))
+	Equal(t, 1, w.Flushes())
+}
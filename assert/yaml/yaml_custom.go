@@ -22,3 +22,5 @@
 package yaml
 
 var Unmarshal func(in []byte, out interface{}) error
+
+var Marshal func(in interface{}) ([]byte, error)
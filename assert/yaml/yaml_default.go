@@ -10,10 +10,12 @@
 //
 // Alternative implementations are selected using build tags:
 //
-//   - testify_yaml_fail: [Unmarshal] always fails with an error
-//   - testify_yaml_custom: [Unmarshal] is a variable. Caller must initialize it
-//     before calling any of [github.com/stretchr/testify/assert.YAMLEq] or
-//     [github.com/stretchr/testify/assert.YAMLEqf].
+//   - testify_yaml_fail: [Unmarshal] and [Marshal] always fail with an error
+//   - testify_yaml_custom: [Unmarshal] and [Marshal] are variables. Caller must
+//     initialize Unmarshal before calling any of
+//     [github.com/stretchr/testify/assert.YAMLEq] or
+//     [github.com/stretchr/testify/assert.YAMLEqf], and both before using a
+//     YAML cassette of [github.com/stretchr/testify/http.CassetteTransport].
 //
 // Usage:
 //
@@ -34,3 +36,8 @@ import goyaml "gopkg.in/yaml.v3"
 func Unmarshal(in []byte, out interface{}) error {
 	return goyaml.Unmarshal(in, out)
 }
+
+// Marshal is just a wrapper of [gopkg.in/yaml.v3.Marshal].
+func Marshal(in interface{}) ([]byte, error) {
+	return goyaml.Marshal(in)
+}
@@ -15,3 +15,7 @@ var errNotImplemented = errors.New("YAML functions are not available (see https:
 func Unmarshal([]byte, interface{}) error {
 	return errNotImplemented
 }
+
+func Marshal(interface{}) ([]byte, error) {
+	return nil, errNotImplemented
+}
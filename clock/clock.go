@@ -0,0 +1,70 @@
+package clock
+
+import "time"
+
+// Clock provides the current time and timers, like the time package does.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a Timer that sends the current time on its channel
+	// after at least duration d.
+	NewTimer(d time.Duration) Timer
+	// NewTicker creates a Ticker that sends the current time on its channel
+	// every period d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the equivalent of a *time.Timer for a Clock.
+type Timer interface {
+	// C returns the channel on which the time is sent.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing. It returns false if the timer
+	// already expired or was stopped.
+	Stop() bool
+	// Reset changes the timer to expire after duration d. It returns true if
+	// the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the equivalent of a *time.Ticker for a Clock.
+type Ticker interface {
+	// C returns the channel on which the ticks are sent.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// Real returns a Clock backed by the time package.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
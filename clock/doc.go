@@ -0,0 +1,25 @@
+// Package clock provides the Clock interface used by the assertions and mocks
+// that wait for time to pass, along with a Fake implementation that tests
+// advance manually.
+//
+// # Example Usage
+//
+//	import (
+//	  "testing"
+//	  "time"
+//
+//	  "github.com/stretchr/testify/assert"
+//	  "github.com/stretchr/testify/clock"
+//	)
+//
+//	func TestSomething(t *testing.T) {
+//	  fake := clock.NewFake(time.Now())
+//
+//	  go func() {
+//	    fake.WaitForTimers(2)
+//	    fake.Advance(time.Minute)
+//	  }()
+//
+//	  assert.Never(assert.WithClock(t, fake), isBroken, time.Minute, time.Second)
+//	}
+package clock
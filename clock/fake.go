@@ -0,0 +1,179 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance or Set is called. Timers
+// and tickers created from it fire when the time is moved past their
+// deadline. It is safe for concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// NewFake returns a Fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// fakeWaiter is a timer or a ticker of a Fake clock. A period of zero means it
+// is a timer.
+type fakeWaiter struct {
+	clock    *Fake
+	c        chan time.Time
+	deadline time.Time
+	period   time.Duration
+}
+
+// Now returns the current time of the fake clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer creates a Timer that fires once the fake clock has been advanced
+// by at least d.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWaiter{clock: f, c: make(chan time.Time, 1)}
+	f.schedule(w, d)
+	return (*fakeTimer)(w)
+}
+
+// NewTicker creates a Ticker that fires each time the fake clock has been
+// advanced by another d. It panics if d is not positive.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWaiter{clock: f, c: make(chan time.Time, 1), period: d}
+	f.schedule(w, d)
+	return (*fakeTicker)(w)
+}
+
+// After returns a channel that receives the time once the fake clock has been
+// advanced by at least d. It can be passed to mock's Call.WaitUntil.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// Advance moves the time of the fake clock forward by d, firing the timers
+// and tickers whose deadline is reached, in order. It panics if d is
+// negative.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	target := f.now.Add(d)
+	f.mu.Unlock()
+	f.Set(target)
+}
+
+// Set moves the time of the fake clock to now, firing the timers and tickers
+// whose deadline is reached, in order. It panics if now is before the time
+// of the fake clock, as time does not go backwards.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if now.Before(f.now) {
+		panic("clock: Fake.Set cannot move the time backwards")
+	}
+
+	for len(f.waiters) > 0 && !f.waiters[0].deadline.After(now) {
+		w := f.waiters[0]
+		f.waiters = f.waiters[1:]
+		f.now = w.deadline
+		select {
+		case w.c <- f.now:
+		default:
+			// Like time.Ticker, drop ticks for slow receivers.
+		}
+		if w.period > 0 {
+			f.schedule(w, w.period)
+		}
+	}
+	f.now = now
+}
+
+// WaitForTimers blocks until at least n timers or tickers are waiting for
+// the fake clock to be advanced. It lets tests make sure that the code they
+// exercise started waiting before calling Advance.
+func (f *Fake) WaitForTimers(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// schedule adds w to the waiters, d after the current time. A timer with a
+// non-positive d fires immediately. f.mu must be held.
+func (f *Fake) schedule(w *fakeWaiter, d time.Duration) {
+	w.deadline = f.now.Add(d)
+	if d <= 0 && w.period == 0 {
+		select {
+		case w.c <- f.now:
+		default:
+		}
+		return
+	}
+	f.waiters = append(f.waiters, w)
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		return f.waiters[i].deadline.Before(f.waiters[j].deadline)
+	})
+	f.cond.Broadcast()
+}
+
+// unschedule removes w from the waiters and returns whether it was active.
+// f.mu must be held.
+func (f *Fake) unschedule(w *fakeWaiter) bool {
+	for i, other := range f.waiters {
+		if other == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer fakeWaiter
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.unschedule((*fakeWaiter)(t))
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.clock.unschedule((*fakeWaiter)(t))
+	t.clock.schedule((*fakeWaiter)(t), d)
+	return active
+}
+
+type fakeTicker fakeWaiter
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.clock.unschedule((*fakeWaiter)(t))
+}
@@ -0,0 +1,159 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRealClock(t *testing.T) {
+	t.Parallel()
+
+	c := Real()
+	if c.Now().IsZero() {
+		t.Error("Now should return the current time")
+	}
+
+	timer := c.NewTimer(time.Millisecond)
+	<-timer.C()
+	if timer.Stop() {
+		t.Error("Stop should return false for an expired timer")
+	}
+
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+}
+
+func TestFakeTimer(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(start)
+	timer := f.NewTimer(time.Second)
+
+	f.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("timer should not fire before its deadline")
+	default:
+	}
+
+	f.Advance(time.Millisecond)
+	select {
+	case now := <-timer.C():
+		if !now.Equal(start.Add(time.Second)) {
+			t.Errorf("timer fired at %v", now)
+		}
+	default:
+		t.Fatal("timer should fire at its deadline")
+	}
+
+	if timer.Stop() {
+		t.Error("Stop should return false for an expired timer")
+	}
+	if timer.Reset(time.Second) {
+		t.Error("Reset should return false for an expired timer")
+	}
+	if !timer.Stop() {
+		t.Error("Stop should return true for an active timer")
+	}
+	f.Advance(time.Hour)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer should not fire")
+	default:
+	}
+}
+
+func TestFakeTimerNonPositiveDuration(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(start)
+	select {
+	case <-f.After(0):
+	default:
+		t.Fatal("timer with a zero duration should fire immediately")
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(start)
+	ticker := f.NewTicker(time.Second)
+
+	for i := 1; i <= 3; i++ {
+		f.Advance(time.Second)
+		select {
+		case now := <-ticker.C():
+			if !now.Equal(start.Add(time.Duration(i) * time.Second)) {
+				t.Errorf("tick %d at %v", i, now)
+			}
+		default:
+			t.Fatalf("tick %d is missing", i)
+		}
+	}
+
+	// Ticks are dropped for slow receivers.
+	f.Advance(5 * time.Second)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Fatal("ticks should be dropped")
+	default:
+	}
+
+	ticker.Stop()
+	f.Advance(time.Hour)
+	select {
+	case <-ticker.C():
+		t.Fatal("stopped ticker should not fire")
+	default:
+	}
+}
+
+func TestFakeSet(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(start)
+	first := f.After(time.Minute)
+	second := f.After(time.Second)
+
+	f.Set(start.Add(time.Hour))
+	if !f.Now().Equal(start.Add(time.Hour)) {
+		t.Errorf("Now should return the time that was set, got %v", f.Now())
+	}
+	if now := <-second; !now.Equal(start.Add(time.Second)) {
+		t.Errorf("second timer fired at %v", now)
+	}
+	if now := <-first; !now.Equal(start.Add(time.Minute)) {
+		t.Errorf("first timer fired at %v", now)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Set should panic when moving the time backwards")
+		}
+		if !f.Now().Equal(start.Add(time.Hour)) {
+			t.Errorf("the time should not have moved, got %v", f.Now())
+		}
+	}()
+	f.Set(start)
+}
+
+func TestFakeWaitForTimers(t *testing.T) {
+	t.Parallel()
+
+	f := NewFake(start)
+	done := make(chan time.Time)
+	go func() {
+		done <- <-f.After(time.Second)
+	}()
+
+	f.WaitForTimers(1)
+	f.Advance(time.Second)
+	if now := <-done; !now.Equal(start.Add(time.Second)) {
+		t.Errorf("timer fired at %v", now)
+	}
+}
@@ -0,0 +1,356 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert/yaml"
+)
+
+// Redacted replaces the values of the headers listed in
+// CassetteTransport.RedactHeaders in a cassette.
+const Redacted = "REDACTED"
+
+// Cassette is the content of a cassette file: the HTTP exchanges recorded by
+// a CassetteTransport, in order.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions" yaml:"interactions"`
+}
+
+// Interaction is a request and the response it got.
+type Interaction struct {
+	Request  CassetteRequest  `json:"request" yaml:"request"`
+	Response CassetteResponse `json:"response" yaml:"response"`
+}
+
+// Base64 is the BodyEncoding of the bodies recorded in base64 because they
+// are not valid UTF-8.
+const Base64 = "base64"
+
+// CassetteRequest is a request recorded in a cassette.
+type CassetteRequest struct {
+	Method string      `json:"method" yaml:"method"`
+	URL    string      `json:"url" yaml:"url"`
+	Header http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	Body   string      `json:"body,omitempty" yaml:"body,omitempty"`
+
+	// BodyEncoding is Base64 if Body is encoded in base64, and empty if Body
+	// is the body itself.
+	BodyEncoding string `json:"body_encoding,omitempty" yaml:"body_encoding,omitempty"`
+}
+
+// CassetteResponse is a response recorded in a cassette.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code" yaml:"status_code"`
+	Header     http.Header `json:"header,omitempty" yaml:"header,omitempty"`
+	Body       string      `json:"body,omitempty" yaml:"body,omitempty"`
+
+	// BodyEncoding is Base64 if Body is encoded in base64, and empty if Body
+	// is the body itself.
+	BodyEncoding string `json:"body_encoding,omitempty" yaml:"body_encoding,omitempty"`
+}
+
+// encodeBody returns body as recorded in a cassette, and its encoding.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), Base64
+}
+
+// decodeBody returns the body recorded in a cassette as body, with encoding.
+func decodeBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case Base64:
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unknown body encoding %q", encoding)
+	}
+}
+
+// RequestMatcher reports whether a request matches a recorded one.
+type RequestMatcher func(req, recorded *CassetteRequest) bool
+
+// MatchMethod matches requests with the same method.
+func MatchMethod(req, recorded *CassetteRequest) bool {
+	return req.Method == recorded.Method
+}
+
+// MatchURL matches requests with the same URL.
+func MatchURL(req, recorded *CassetteRequest) bool {
+	return req.URL == recorded.URL
+}
+
+// MatchBody matches requests with the same body.
+func MatchBody(req, recorded *CassetteRequest) bool {
+	return req.Body == recorded.Body && req.BodyEncoding == recorded.BodyEncoding
+}
+
+// CassetteTransport is an http.RoundTripper that records the HTTP exchanges
+// of a test in a cassette file the first time it runs, and replays them from
+// the file afterwards so that the test runs offline.
+//
+// The cassette is a JSON file if its name ends with ".json", and a YAML file if
+// it ends with ".yaml" or ".yml". YAML cassettes use the
+// [github.com/stretchr/testify/assert/yaml] indirection.
+//
+// Each recorded interaction is replayed once, for the first request that
+// matches it. While the cassette file does not exist, the requests are sent
+// with Transport and recorded, unless Strict is set. Once it is loaded, a
+// request that matches no interaction left fails, unless RecordNew is set.
+// Delete the cassette file to record it again.
+//
+// Bodies that are not valid UTF-8 are recorded in base64, with a
+// BodyEncoding of Base64.
+//
+//	transport, err := httpmock.NewCassetteTransport("testdata/github.yaml")
+//	require.NoError(t, err)
+//	transport.RedactHeaders = []string{"Authorization"}
+//
+//	client := &http.Client{Transport: transport}
+type CassetteTransport struct {
+	// Transport sends the requests to record. If nil, http.DefaultTransport
+	// is used.
+	Transport http.RoundTripper
+
+	// Match lists the conditions for a request to match a recorded one. If
+	// empty, requests match on their method and URL.
+	Match []RequestMatcher
+
+	// RedactHeaders lists the request and response headers whose values are
+	// replaced with Redacted in the cassette.
+	RedactHeaders []string
+
+	// Strict makes requests that match no recorded interaction fail instead
+	// of being sent and recorded, even while the cassette file does not
+	// exist.
+	Strict bool
+
+	// RecordNew makes requests that match no interaction of a loaded
+	// cassette be sent and appended to the cassette instead of failing.
+	// Strict takes precedence.
+	RecordNew bool
+
+	mutex    sync.Mutex
+	path     string
+	codec    codec
+	cassette Cassette
+	played   []bool
+	loaded   bool
+}
+
+// NewCassetteTransport returns a transport for the cassette file at path,
+// which is loaded if it exists.
+func NewCassetteTransport(path string) (*CassetteTransport, error) {
+	codec, err := cassetteCodec(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &CassetteTransport{path: path, codec: codec}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	if err := codec.unmarshal(content, &c.cassette); err != nil {
+		return nil, fmt.Errorf("cassette: decoding %s: %w", path, err)
+	}
+	for i, interaction := range c.cassette.Interactions {
+		if _, err := decodeBody(interaction.Request.Body, interaction.Request.BodyEncoding); err != nil {
+			return nil, fmt.Errorf("cassette: decoding %s: request %d: %w", path, i, err)
+		}
+		if _, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding); err != nil {
+			return nil, fmt.Errorf("cassette: decoding %s: response %d: %w", path, i, err)
+		}
+	}
+	c.played = make([]bool, len(c.cassette.Interactions))
+	c.loaded = true
+	return c, nil
+}
+
+// codec encodes and decodes a cassette file.
+type codec struct {
+	marshal   func(interface{}) ([]byte, error)
+	unmarshal func([]byte, interface{}) error
+}
+
+// cassetteCodec returns the codec for the format of the cassette file at path.
+func cassetteCodec(path string) (codec, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return codec{
+			marshal: func(v interface{}) ([]byte, error) {
+				return json.MarshalIndent(v, "", "  ")
+			},
+			unmarshal: json.Unmarshal,
+		}, nil
+	case ".yaml", ".yml":
+		return codec{marshal: yaml.Marshal, unmarshal: yaml.Unmarshal}, nil
+	default:
+		return codec{}, fmt.Errorf("cassette: unknown format of %s, expected a .json, .yaml or .yml file", path)
+	}
+}
+
+// Interactions returns the interactions in the cassette.
+func (c *CassetteTransport) Interactions() []*Interaction {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]*Interaction(nil), c.cassette.Interactions...)
+}
+
+// RoundTrip returns the recorded response of the first interaction left that
+// matches req. If there is none, req is sent and recorded if the cassette was
+// not loaded or RecordNew is set, and c is not strict.
+func (c *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cassette: reading request body: %w", err)
+		}
+	}
+	recorded := CassetteRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: c.redact(req.Header),
+	}
+	recorded.Body, recorded.BodyEncoding = encodeBody(body)
+
+	if resp, ok := c.replay(req, &recorded); ok {
+		return resp, nil
+	}
+	if c.Strict || c.loaded && !c.RecordNew {
+		return nil, fmt.Errorf("cassette: no recorded interaction in %s matches %s %s", c.path, recorded.Method, recorded.URL)
+	}
+
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     c.redact(resp.Header),
+		},
+	}
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(respBody)
+	if err := c.record(interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replay returns the response of the first interaction left that matches req.
+func (c *CassetteTransport) replay(req *http.Request, recorded *CassetteRequest) (*http.Response, bool) {
+	match := c.Match
+	if len(match) == 0 {
+		match = []RequestMatcher{MatchMethod, MatchURL}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+interactions:
+	for i, interaction := range c.cassette.Interactions {
+		if c.played[i] {
+			continue
+		}
+		for _, m := range match {
+			if !m(recorded, &interaction.Request) {
+				continue interactions
+			}
+		}
+
+		c.played[i] = true
+		r := interaction.Response
+		// The bodies of the interactions are checked when the cassette is
+		// loaded or recorded.
+		body, _ := decodeBody(r.Body, r.BodyEncoding)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+			StatusCode:    r.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        r.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, true
+	}
+	return nil, false
+}
+
+// record appends interaction to the cassette and saves it.
+func (c *CassetteTransport) record(interaction *Interaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cassette.Interactions = append(c.cassette.Interactions, interaction)
+	c.played = append(c.played, true)
+
+	content, err := c.codec.marshal(&c.cassette)
+	if err != nil {
+		return fmt.Errorf("cassette: encoding %s: %w", c.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := os.WriteFile(c.path, content, 0o644); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return nil
+}
+
+// redact returns a copy of header with the values of c.RedactHeaders
+// replaced.
+func (c *CassetteTransport) redact(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	header = header.Clone()
+	for _, key := range c.RedactHeaders {
+		if values := header.Values(key); len(values) > 0 {
+			redacted := make([]string, len(values))
+			for i := range redacted {
+				redacted[i] = Redacted
+			}
+			header[http.CanonicalHeaderKey(key)] = redacted
+		}
+	}
+	return header
+}
@@ -0,0 +1,221 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// offline is a transport for replays, that fails all the requests.
+type offline struct{}
+
+func (offline) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("offline")
+}
+
+// countingServer starts a server that answers each request with its method,
+// body and number.
+func countingServer(t *testing.T) *httptest.Server {
+	var n int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Set-Cookie", "session=s3cr3t")
+		_, _ = fmt.Fprintf(w, "%s %s #%d", r.Method, body, atomic.AddInt32(&n, 1))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func post(t *testing.T, client *http.Client, url, body string) string {
+	req, err := http.NewRequest("POST", url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestCassetteTransport(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"api.json", "api.yaml"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := countingServer(t)
+			path := filepath.Join(t.TempDir(), "testdata", name)
+
+			transport, err := NewCassetteTransport(path)
+			require.NoError(t, err)
+			transport.RedactHeaders = []string{"authorization", "Set-Cookie"}
+			client := &http.Client{Transport: transport}
+			assert.Equal(t, "POST a #1", post(t, client, server.URL+"/items", "a"))
+			assert.Equal(t, "POST a #2", post(t, client, server.URL+"/items", "a"))
+
+			interactions := transport.Interactions()
+			require.Len(t, interactions, 2)
+			assert.Equal(t, []string{Redacted}, interactions[0].Request.Header["Authorization"])
+			assert.Equal(t, []string{Redacted}, interactions[0].Response.Header["Set-Cookie"])
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.NotContains(t, string(content), "s3cr3t")
+			assert.NotContains(t, string(content), "Bearer")
+
+			// Replay in order, without sending anything
+			transport, err = NewCassetteTransport(path)
+			require.NoError(t, err)
+			transport.Transport = offline{}
+			client = &http.Client{Transport: transport}
+			assert.Equal(t, "POST a #1", post(t, client, server.URL+"/items", "a"))
+			assert.Equal(t, "POST a #2", post(t, client, server.URL+"/items", "a"))
+
+			_, err = client.Get(server.URL + "/items")
+			assert.ErrorContains(t, err, fmt.Sprintf("cassette: no recorded interaction in %s matches GET %s/items", path, server.URL))
+		})
+	}
+}
+
+func TestCassetteTransportRecordNew(t *testing.T) {
+	t.Parallel()
+
+	server := countingServer(t)
+	path := filepath.Join(t.TempDir(), "api.json")
+
+	transport, err := NewCassetteTransport(path)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+	assert.Equal(t, "POST a #1", post(t, client, server.URL, "a"))
+
+	transport, err = NewCassetteTransport(path)
+	require.NoError(t, err)
+	transport.RecordNew = true
+	client = &http.Client{Transport: transport}
+	assert.Equal(t, "POST a #1", post(t, client, server.URL, "a"))
+	assert.Equal(t, "POST b #2", post(t, client, server.URL, "b"))
+	assert.Len(t, transport.Interactions(), 2)
+
+	transport, err = NewCassetteTransport(path)
+	require.NoError(t, err)
+	transport.RecordNew = true
+	transport.Strict = true
+	client = &http.Client{Transport: transport}
+	assert.Equal(t, "POST a #1", post(t, client, server.URL, "a"))
+	assert.Equal(t, "POST b #2", post(t, client, server.URL, "b"))
+	_, err = client.Post(server.URL, "text/plain", strings.NewReader("c"))
+	assert.ErrorContains(t, err, "cassette: no recorded interaction")
+}
+
+func TestCassetteTransportBinaryBody(t *testing.T) {
+	t.Parallel()
+
+	binary := []byte{0xff, 0xfe, 0x00, 'a', 0x80}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(append(body, binary...))
+	}))
+	t.Cleanup(server.Close)
+
+	for _, name := range []string{"api.json", "api.yaml"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), name)
+			transport, err := NewCassetteTransport(path)
+			require.NoError(t, err)
+			client := &http.Client{Transport: transport}
+			want := string(binary) + string(binary)
+			assert.Equal(t, want, post(t, client, server.URL, string(binary)))
+
+			interactions := transport.Interactions()
+			require.Len(t, interactions, 1)
+			assert.Equal(t, Base64, interactions[0].Request.BodyEncoding)
+			assert.Equal(t, Base64, interactions[0].Response.BodyEncoding)
+
+			transport, err = NewCassetteTransport(path)
+			require.NoError(t, err)
+			transport.Match = []RequestMatcher{MatchMethod, MatchURL, MatchBody}
+			transport.Transport = offline{}
+			client = &http.Client{Transport: transport}
+			assert.Equal(t, want, post(t, client, server.URL, string(binary)))
+		})
+	}
+}
+
+func TestCassetteTransportStrict(t *testing.T) {
+	t.Parallel()
+
+	server := countingServer(t)
+	path := filepath.Join(t.TempDir(), "api.yml")
+
+	transport, err := NewCassetteTransport(path)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+	assert.Equal(t, "POST a #1", post(t, client, server.URL, "a"))
+
+	transport, err = NewCassetteTransport(path)
+	require.NoError(t, err)
+	transport.Strict = true
+	client = &http.Client{Transport: transport}
+	assert.Equal(t, "POST a #1", post(t, client, server.URL, "a"))
+
+	_, err = client.Post(server.URL, "text/plain", strings.NewReader("a"))
+	assert.ErrorContains(t, err, fmt.Sprintf("cassette: no recorded interaction in %s matches POST %s", path, server.URL))
+}
+
+func TestCassetteTransportMatch(t *testing.T) {
+	t.Parallel()
+
+	server := countingServer(t)
+	path := filepath.Join(t.TempDir(), "api.json")
+
+	transport, err := NewCassetteTransport(path)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+	post(t, client, server.URL, "a")
+	post(t, client, server.URL, "b")
+
+	transport, err = NewCassetteTransport(path)
+	require.NoError(t, err)
+	transport.Match = []RequestMatcher{MatchMethod, MatchURL, MatchBody}
+	transport.Strict = true
+	client = &http.Client{Transport: transport}
+	assert.Equal(t, "POST b #2", post(t, client, server.URL, "b"))
+	assert.Equal(t, "POST a #1", post(t, client, server.URL, "a"))
+}
+
+func TestNewCassetteTransportErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCassetteTransport("api.txt")
+	assert.EqualError(t, err, "cassette: unknown format of api.txt, expected a .json, .yaml or .yml file")
+
+	path := filepath.Join(t.TempDir(), "api.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = NewCassetteTransport(path)
+	assert.ErrorContains(t, err, "cassette: decoding "+path)
+}
+
+func TestNewCassetteTransportBodyEncoding(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "api.json")
+	content := `{"interactions": [{"request": {"method": "GET", "url": "/"}, "response": {"status_code": 200, "body": "x", "body_encoding": "hex"}}]}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	_, err := NewCassetteTransport(path)
+	assert.EqualError(t, err, "cassette: decoding "+path+": response 0: unknown body encoding \"hex\"")
+}
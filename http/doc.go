@@ -1,2 +1,8 @@
-// Deprecated: Use [net/http/httptest] instead.
+// Package http provides an http.RoundTripper to fake the servers that the code
+// under test sends requests to.
+//
+// The package used to be deprecated in favor of [net/http/httptest]. It is no
+// longer deprecated since the addition of [MockTransport], which httptest has
+// no equivalent for. Only TestResponseWriter and TestRoundTripper remain
+// deprecated: use [net/http/httptest] and [MockTransport] instead.
 package http
@@ -0,0 +1,293 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransport is an http.RoundTripper that answers requests with canned
+// responses instead of sending them. Expectations are registered with On,
+// every request is recorded, and AssertExpectations reports the expectations
+// that were not met and the requests that matched none of them. It can be
+// passed to mock.AssertExpectationsForObjects with other mocks.
+//
+// With this package imported as httpmock:
+//
+//	transport := new(httpmock.MockTransport)
+//	transport.On("GET", `/users/\d+`).
+//		Header("Content-Type", "application/json").
+//		Return(200, `{"name": "Ana"}`)
+//
+//	client := &http.Client{Transport: transport}
+//	// ... code under test using client ...
+//
+//	transport.AssertExpectations(t)
+type MockTransport struct {
+	mutex        sync.Mutex
+	expectations []*Expectation
+	requests     []*RecordedRequest
+}
+
+// Expectation is a request expected by a MockTransport, and the response it
+// gets. It is created with MockTransport.On, and each of its methods returns
+// the expectation so that calls can be chained.
+type Expectation struct {
+	transport *MockTransport
+
+	method  string
+	pattern string
+	url     *regexp.Regexp
+
+	status   int
+	header   http.Header
+	body     []byte
+	bodyFile string
+	err      error
+
+	// times is the number of times the expectation can be matched, or 0 if
+	// there is no limit.
+	times int
+	calls int
+}
+
+// RecordedRequest is a request received by a MockTransport.
+type RecordedRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+
+	// Matched is false if the request matched no expectation.
+	Matched bool
+}
+
+func (r *RecordedRequest) String() string {
+	return r.Method + " " + r.URL.String()
+}
+
+// On registers an expectation for requests with the given method and a URL
+// matching urlPattern. An empty method matches any method. urlPattern is a
+// regular expression that must match the whole URL, or only its path and
+// query if the pattern starts with "/". The response is an empty 200 OK until
+// set otherwise.
+//
+//	transport.On("POST", `https://api\.example\.com/users`).Return(201, "")
+func (m *MockTransport) On(method, urlPattern string) *Expectation {
+	e := &Expectation{
+		transport: m,
+		method:    method,
+		pattern:   urlPattern,
+		url:       regexp.MustCompile("^(?:" + urlPattern + ")$"),
+		status:    http.StatusOK,
+		header:    http.Header{},
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+func (e *Expectation) lock() {
+	e.transport.mutex.Lock()
+}
+
+func (e *Expectation) unlock() {
+	e.transport.mutex.Unlock()
+}
+
+// Return sets the status code and the body of the response.
+func (e *Expectation) Return(status int, body string) *Expectation {
+	e.lock()
+	defer e.unlock()
+	e.status = status
+	e.body = []byte(body)
+	e.bodyFile = ""
+	return e
+}
+
+// ReturnFile sets the status code of the response, and its body to the
+// content of the named file. The file is read each time a request matches.
+func (e *Expectation) ReturnFile(status int, name string) *Expectation {
+	e.lock()
+	defer e.unlock()
+	e.status = status
+	e.body = nil
+	e.bodyFile = name
+	return e
+}
+
+// ReturnError makes the requests that match fail with err instead of getting
+// a response.
+func (e *Expectation) ReturnError(err error) *Expectation {
+	e.lock()
+	defer e.unlock()
+	e.err = err
+	return e
+}
+
+// Header adds a header to the response.
+func (e *Expectation) Header(key, value string) *Expectation {
+	e.lock()
+	defer e.unlock()
+	e.header.Add(key, value)
+	return e
+}
+
+// Once makes the expectation match only one request.
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// Times makes the expectation match only n requests. Further requests are
+// matched against the other expectations.
+func (e *Expectation) Times(n int) *Expectation {
+	e.lock()
+	defer e.unlock()
+	e.times = n
+	return e
+}
+
+func (e *Expectation) String() string {
+	method := e.method
+	if method == "" {
+		method = "*"
+	}
+	return method + " " + e.pattern
+}
+
+func (e *Expectation) matches(req *http.Request) bool {
+	if e.method != "" && !strings.EqualFold(e.method, req.Method) {
+		return false
+	}
+	if e.times > 0 && e.calls >= e.times {
+		return false
+	}
+	if strings.HasPrefix(e.pattern, "/") {
+		return e.url.MatchString(req.URL.RequestURI())
+	}
+	return e.url.MatchString(req.URL.String())
+}
+
+func (e *Expectation) response(req *http.Request) (*http.Response, error) {
+	e.lock()
+	status, header, body, bodyFile, err := e.status, e.header.Clone(), e.body, e.bodyFile, e.err
+	e.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	if bodyFile != "" {
+		if body, err = os.ReadFile(bodyFile); err != nil {
+			return nil, fmt.Errorf("mock transport: reading response body: %w", err)
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// RoundTrip records req and returns the response of the first expectation it
+// matches, in the order they were registered. If it matches none, an error is
+// returned and the request is reported by AssertExpectations.
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := &RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL,
+		Header: req.Header.Clone(),
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("mock transport: reading request body: %w", err)
+		}
+		recorded.Body = body
+	}
+
+	m.mutex.Lock()
+	m.requests = append(m.requests, recorded)
+	var matched *Expectation
+	for _, e := range m.expectations {
+		if e.matches(req) {
+			matched = e
+			matched.calls++
+			break
+		}
+	}
+	recorded.Matched = matched != nil
+	m.mutex.Unlock()
+
+	if matched == nil {
+		return nil, fmt.Errorf("mock transport: no expectation matches %s", recorded)
+	}
+	return matched.response(req)
+}
+
+// Requests returns the requests received so far, in order.
+func (m *MockTransport) Requests() []*RecordedRequest {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]*RecordedRequest(nil), m.requests...)
+}
+
+// AssertExpectations asserts that every expectation was matched, as many
+// times as set with Times or at least once otherwise, and that every request
+// matched an expectation.
+func (m *MockTransport) AssertExpectations(t mock.TestingT) bool {
+	if s, ok := t.(interface{ Skipped() bool }); ok && s.Skipped() {
+		return true
+	}
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var msg strings.Builder
+	met := 0
+	for _, e := range m.expectations {
+		switch {
+		case e.times > 0 && e.calls != e.times:
+			fmt.Fprintf(&msg, "\tFAIL:\t%s (matched %d of %d times)\n", e, e.calls, e.times)
+		case e.calls == 0:
+			fmt.Fprintf(&msg, "\tFAIL:\t%s (never matched)\n", e)
+		default:
+			met++
+		}
+	}
+
+	var unmatched []string
+	for _, r := range m.requests {
+		if !r.Matched {
+			unmatched = append(unmatched, "\t\t"+r.String())
+		}
+	}
+
+	if met == len(m.expectations) && len(unmatched) == 0 {
+		return true
+	}
+	if len(unmatched) > 0 {
+		fmt.Fprintf(&msg, "\t%d unmatched request(s):\n%s\n", len(unmatched), strings.Join(unmatched, "\n"))
+	}
+	t.Errorf("FAIL: %d out of %d expectation(s) were met.\n%s", met, len(m.expectations), msg.String())
+	return false
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// captureT records the failures reported to it.
+type captureT struct {
+	msg string
+}
+
+func (t *captureT) Logf(format string, args ...interface{}) {}
+
+func (t *captureT) Errorf(format string, args ...interface{}) {
+	t.msg += fmt.Sprintf(format, args...)
+}
+
+func (t *captureT) FailNow() {}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	resp, err := client.Get(url)
+	if !assert.NoError(t, err) {
+		return 0, ""
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestMockTransport(t *testing.T) {
+	t.Parallel()
+
+	transport := new(MockTransport)
+	transport.On("GET", `/users/\d+`).
+		Header("Content-Type", "application/json").
+		Return(http.StatusOK, `{"name": "Ana"}`)
+	transport.On("POST", `https://api\.example\.com/users`).Return(http.StatusCreated, "").Once()
+	transport.On("", `/files/.*`).ReturnFile(http.StatusOK, "testdata/user.json")
+	client := &http.Client{Transport: transport}
+
+	status, body := get(t, client, "https://api.example.com/users/1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"name": "Ana"}`, body)
+	status, _ = get(t, client, "https://api.example.com/users/2")
+	assert.Equal(t, http.StatusOK, status)
+
+	resp, err := client.Post("https://api.example.com/users", "application/json", strings.NewReader(`{"name": "Bob"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	status, body = get(t, client, "https://cdn.example.com/files/user.json")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "{\"id\": 1}\n", body)
+
+	requests := transport.Requests()
+	assert.Len(t, requests, 4)
+	assert.Equal(t, "POST https://api.example.com/users", requests[2].String())
+	assert.Equal(t, `{"name": "Bob"}`, string(requests[2].Body))
+	assert.Equal(t, "application/json", requests[2].Header.Get("Content-Type"))
+	assert.True(t, requests[2].Matched)
+
+	assert.True(t, transport.AssertExpectations(t))
+	assert.True(t, mock.AssertExpectationsForObjects(t, transport))
+}
+
+func TestMockTransportUnmatched(t *testing.T) {
+	t.Parallel()
+
+	transport := new(MockTransport)
+	transport.On("GET", `/health`).Return(http.StatusOK, "ok")
+	transport.On("DELETE", `/users/\d+`).Times(2)
+	transport.On("PUT", `/users/\d+`).Once()
+	client := &http.Client{Transport: transport}
+
+	_, err := client.Get("https://api.example.com/users/1")
+	assert.ErrorContains(t, err, "mock transport: no expectation matches GET https://api.example.com/users/1")
+
+	req, _ := http.NewRequest("DELETE", "https://api.example.com/users/1", nil)
+	_, err = client.Do(req)
+	assert.NoError(t, err)
+
+	// Once used, an expectation limited with Times no longer matches
+	req, _ = http.NewRequest("PUT", "https://api.example.com/users/1", nil)
+	_, err = client.Do(req)
+	assert.NoError(t, err)
+	_, err = client.Do(req)
+	assert.Error(t, err)
+
+	mockT := new(captureT)
+	assert.False(t, transport.AssertExpectations(mockT))
+	assert.Equal(t, "FAIL: 1 out of 3 expectation(s) were met.\n"+
+		"\tFAIL:\tGET /health (never matched)\n"+
+		"\tFAIL:\tDELETE /users/\\d+ (matched 1 of 2 times)\n"+
+		"\t2 unmatched request(s):\n"+
+		"\t\tGET https://api.example.com/users/1\n"+
+		"\t\tPUT https://api.example.com/users/1\n", mockT.msg)
+}
+
+func TestMockTransportErrors(t *testing.T) {
+	t.Parallel()
+
+	transport := new(MockTransport)
+	transport.On("GET", `/down`).ReturnError(errors.New("connection refused"))
+	transport.On("GET", `/missing`).ReturnFile(http.StatusOK, "testdata/missing.json")
+	client := &http.Client{Transport: transport}
+
+	_, err := client.Get("https://api.example.com/down")
+	assert.ErrorContains(t, err, "connection refused")
+	_, err = client.Get("https://api.example.com/missing")
+	assert.ErrorContains(t, err, "mock transport: reading response body: open testdata/missing.json")
+
+	assert.True(t, transport.AssertExpectations(t))
+}
+
+func TestTestRoundTripperNilResponse(t *testing.T) {
+	t.Parallel()
+
+	rt := new(TestRoundTripper)
+	rt.On("RoundTrip", mock.Anything).Return(nil, errors.New("refused"))
+
+	req, _ := http.NewRequest("GET", "https://api.example.com", nil)
+	resp, err := rt.RoundTrip(req)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "refused")
+}
+
+func TestMockTransportConcurrentExpectation(t *testing.T) {
+	t.Parallel()
+
+	transport := new(MockTransport)
+	e := transport.On("GET", `/ping`)
+	client := &http.Client{Transport: transport}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			e.Header("X-Count", fmt.Sprint(i)).Return(http.StatusOK, "pong")
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		status, _ := get(t, client, "https://example.com/ping")
+		assert.Equal(t, http.StatusOK, status)
+	}
+	<-done
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 )
 
-// Deprecated: Use [net/http/httptest] instead.
+// Deprecated: Use [net/http/httptest] instead, or
+// [github.com/stretchr/testify/assert.StreamRecorder] for handlers that flush
+// their response.
 type TestResponseWriter struct {
 
 	// StatusCode is the last int written by the call to WriteHeader(int)
@@ -6,13 +6,14 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// Deprecated: Use [net/http/httptest] instead.
+// Deprecated: Use [MockTransport] instead.
 type TestRoundTripper struct {
 	mock.Mock
 }
 
-// Deprecated: Use [net/http/httptest] instead.
+// Deprecated: Use [MockTransport] instead.
 func (t *TestRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	args := t.Called(req)
-	return args.Get(0).(*http.Response), args.Error(1)
+	resp, _ := args.Get(0).(*http.Response)
+	return resp, args.Error(1)
 }
@@ -0,0 +1,137 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ArgumentCaptor is an expected argument that matches any argument, like
+// Anything, and records the arguments of the calls it matched so that they
+// can be inspected afterwards. It is safe for concurrent use.
+//
+//	saved := mock.Capture()
+//	store.On("Save", saved).Return(nil)
+//	// ... code under test ...
+//	user := saved.Last().(*User)
+type ArgumentCaptor struct {
+	mutex  sync.Mutex
+	values []interface{}
+}
+
+// Capture returns a new ArgumentCaptor, to use as an expected argument.
+func Capture() *ArgumentCaptor {
+	return &ArgumentCaptor{}
+}
+
+// Matches matches any argument. The argument is only captured once the
+// call is matched by all its arguments.
+func (c *ArgumentCaptor) Matches(interface{}) bool {
+	return true
+}
+
+// String describes the captor in the differences of the arguments.
+func (c *ArgumentCaptor) String() string {
+	return "mock.Capture()"
+}
+
+func (c *ArgumentCaptor) capture(value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values = append(c.values, value)
+}
+
+// Len returns the number of arguments captured.
+func (c *ArgumentCaptor) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.values)
+}
+
+// All returns the arguments captured, in the order of the calls.
+func (c *ArgumentCaptor) All() []interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]interface{}(nil), c.values...)
+}
+
+// Last returns the argument of the last call captured. It panics if no
+// argument was captured.
+func (c *ArgumentCaptor) Last() interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.values) == 0 {
+		panic("mock: ArgumentCaptor.Last() failed because no argument was captured")
+	}
+	return c.values[len(c.values)-1]
+}
+
+// LastAs sets the value pointed to by target, a non-nil pointer, to the
+// argument of the last call captured. It panics if no argument was captured
+// or if the argument cannot be assigned to the value.
+//
+//	var user *User
+//	saved.LastAs(&user)
+func (c *ArgumentCaptor) LastAs(target interface{}) {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Sprintf("mock: ArgumentCaptor.LastAs(%T) failed because the target is not a non-nil pointer", target))
+	}
+	last := c.Last()
+	elem := v.Elem()
+	if last == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return
+	}
+	if !reflect.TypeOf(last).AssignableTo(elem.Type()) {
+		panic(fmt.Sprintf("mock: ArgumentCaptor.LastAs(%T) failed because the argument is %T", target, last))
+	}
+	elem.Set(reflect.ValueOf(last))
+}
+
+// LastString returns the argument of the last call captured, which must be
+// a string.
+func (c *ArgumentCaptor) LastString() string {
+	last := c.Last()
+	s, ok := last.(string)
+	if !ok {
+		panic(fmt.Sprintf("mock: ArgumentCaptor.LastString() failed because the argument is %T", last))
+	}
+	return s
+}
+
+// LastInt returns the argument of the last call captured, which must be an
+// int.
+func (c *ArgumentCaptor) LastInt() int {
+	last := c.Last()
+	i, ok := last.(int)
+	if !ok {
+		panic(fmt.Sprintf("mock: ArgumentCaptor.LastInt() failed because the argument is %T", last))
+	}
+	return i
+}
+
+// LastBool returns the argument of the last call captured, which must be a
+// bool.
+func (c *ArgumentCaptor) LastBool() bool {
+	last := c.Last()
+	b, ok := last.(bool)
+	if !ok {
+		panic(fmt.Sprintf("mock: ArgumentCaptor.LastBool() failed because the argument is %T", last))
+	}
+	return b
+}
+
+// LastError returns the argument of the last call captured, which must be
+// an error or nil.
+func (c *ArgumentCaptor) LastError() error {
+	last := c.Last()
+	if last == nil {
+		return nil
+	}
+	err, ok := last.(error)
+	if !ok {
+		panic(fmt.Sprintf("mock: ArgumentCaptor.LastError() failed because the argument is %T", last))
+	}
+	return err
+}
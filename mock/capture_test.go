@@ -0,0 +1,92 @@
+package mock
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapture(t *testing.T) {
+	t.Parallel()
+
+	m := new(TestExampleImplementation)
+	a, b := Capture(), Capture()
+	m.On("TheExampleMethod", 1, a, b).Return(0, nil)
+	m.On("TheExampleMethod", 2, Anything, Anything).Return(0, nil)
+
+	_, _ = m.TheExampleMethod(1, 2, 3)
+	_, _ = m.TheExampleMethod(1, 4, 5)
+	// Matched by the other expectation
+	_, _ = m.TheExampleMethod(2, 6, 7)
+	m.AssertCalled(t, "TheExampleMethod", 1, a, b)
+
+	assert.Equal(t, 2, a.Len())
+	assert.Equal(t, []interface{}{2, 4}, a.All())
+	assert.Equal(t, 4, a.Last())
+	assert.Equal(t, 5, b.LastInt())
+	var c int
+	b.LastAs(&c)
+	assert.Equal(t, 5, c)
+
+	diff, count := Arguments{1, a}.Diff([]interface{}{1, "x"})
+	assert.Equal(t, 0, count)
+	assert.Equal(t, "No differences.", diff)
+	diff, _ = Arguments{2, a}.Diff([]interface{}{1, "x"})
+	assert.Contains(t, diff, "\t1: PASS:  (string=x) matched by mock.Capture()\n")
+}
+
+func TestCaptureConcurrent(t *testing.T) {
+	t.Parallel()
+
+	m := new(TestExampleImplementation)
+	yes := Capture()
+	m.On("TheExampleMethod2", yes)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.TheExampleMethod2(i%2 == 0)
+			_ = yes.All()
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10, yes.Len())
+}
+
+func TestCaptureAccessors(t *testing.T) {
+	t.Parallel()
+
+	c := Capture()
+	assert.PanicsWithValue(t, "mock: ArgumentCaptor.Last() failed because no argument was captured", func() {
+		c.Last()
+	})
+	assert.Empty(t, c.All())
+
+	c.capture("a")
+	assert.Equal(t, "a", c.LastString())
+	assert.PanicsWithValue(t, "mock: ArgumentCaptor.LastInt() failed because the argument is string", func() {
+		c.LastInt()
+	})
+	var n int
+	assert.PanicsWithValue(t, "mock: ArgumentCaptor.LastAs(*int) failed because the argument is string", func() {
+		c.LastAs(&n)
+	})
+	assert.PanicsWithValue(t, "mock: ArgumentCaptor.LastAs(int) failed because the target is not a non-nil pointer", func() {
+		c.LastAs(n)
+	})
+
+	c.capture(true)
+	assert.True(t, c.LastBool())
+
+	c.capture(nil)
+	assert.NoError(t, c.LastError())
+	var err error = errors.New("failed")
+	c.LastAs(&err)
+	assert.NoError(t, err)
+	c.capture(errors.New("failed"))
+	assert.EqualError(t, c.LastError(), "failed")
+}
@@ -0,0 +1,320 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// describedMatcher is a matcher built from a predicate and its description.
+type describedMatcher struct {
+	description string
+	fn          func(actual interface{}) bool
+}
+
+func (m describedMatcher) Matches(argument interface{}) bool {
+	return m.fn(argument)
+}
+
+func (m describedMatcher) String() string {
+	return m.description
+}
+
+// matchArgument reports whether actual is matched by expected, a value or a
+// matcher such as Anything, with the rules of Arguments.Diff.
+func matchArgument(expected, actual interface{}) bool {
+	_, differences := Arguments{expected}.Diff([]interface{}{actual})
+	return differences == 0
+}
+
+// describe returns the description of expected, a value or a matcher, in the
+// descriptions of the matchers built on it.
+func describe(expected interface{}) string {
+	switch expected := expected.(type) {
+	case matcher:
+		return expected.String()
+	case anythingOfTypeArgument:
+		return fmt.Sprintf("mock.AnythingOfType(%q)", string(expected))
+	case *IsTypeArgument:
+		return fmt.Sprintf("mock.IsType(%s)", safeTypeName(expected.t))
+	}
+	if expected == Anything {
+		return "mock.Anything"
+	}
+	return fmt.Sprintf("%#v", expected)
+}
+
+func describeAll(expected []interface{}) string {
+	descriptions := make([]string, len(expected))
+	for i, e := range expected {
+		descriptions[i] = describe(e)
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// Not matches the arguments not matched by expected, a value or a matcher.
+//
+//	Mock.On("Delete", mock.Not(0))
+func Not(expected interface{}) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.Not(%s)", describe(expected)),
+		fn: func(actual interface{}) bool {
+			return !matchArgument(expected, actual)
+		},
+	}
+}
+
+// And matches the arguments matched by all of expected, values or matchers.
+//
+//	Mock.On("Resize", mock.And(mock.InRange(1, 100), mock.Not(50)))
+func And(expected ...interface{}) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.And(%s)", describeAll(expected)),
+		fn: func(actual interface{}) bool {
+			for _, e := range expected {
+				if !matchArgument(e, actual) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// Or matches the arguments matched by any of expected, values or matchers.
+//
+//	Mock.On("Get", mock.Or("ana", "bob"))
+func Or(expected ...interface{}) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.Or(%s)", describeAll(expected)),
+		fn: func(actual interface{}) bool {
+			for _, e := range expected {
+				if matchArgument(e, actual) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// Regex matches the arguments matching the regexp rx, a string or a
+// *regexp.Regexp. A []byte argument is matched as is, other arguments as
+// formatted by fmt.Sprint.
+//
+//	Mock.On("Send", mock.Regex(`^\d{3} `))
+func Regex(rx interface{}) describedMatcher {
+	r, ok := rx.(*regexp.Regexp)
+	if !ok {
+		r = regexp.MustCompile(fmt.Sprint(rx))
+	}
+	return describedMatcher{
+		description: fmt.Sprintf("mock.Regex(%q)", r.String()),
+		fn: func(actual interface{}) bool {
+			if b, ok := actual.([]byte); ok {
+				return r.Match(b)
+			}
+			return r.MatchString(fmt.Sprint(actual))
+		},
+	}
+}
+
+// Contains matches the strings containing the string element, the slices
+// and arrays with an element matched by element, a value or a matcher, and
+// the maps with such a key.
+//
+//	Mock.On("Notify", mock.Contains("admin"))
+func Contains(element interface{}) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.Contains(%s)", describe(element)),
+		fn: func(actual interface{}) bool {
+			v := reflect.ValueOf(actual)
+			switch v.Kind() {
+			case reflect.String:
+				s, ok := element.(string)
+				return ok && strings.Contains(v.String(), s)
+			case reflect.Slice, reflect.Array:
+				for i := 0; i < v.Len(); i++ {
+					if matchArgument(element, v.Index(i).Interface()) {
+						return true
+					}
+				}
+			case reflect.Map:
+				for _, key := range v.MapKeys() {
+					if matchArgument(element, key.Interface()) {
+						return true
+					}
+				}
+			}
+			return false
+		},
+	}
+}
+
+// HasPrefix matches the strings, of any string type, starting with prefix.
+//
+//	Mock.On("Get", mock.HasPrefix("/users/"))
+func HasPrefix(prefix string) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.HasPrefix(%q)", prefix),
+		fn: func(actual interface{}) bool {
+			v := reflect.ValueOf(actual)
+			return v.Kind() == reflect.String && strings.HasPrefix(v.String(), prefix)
+		},
+	}
+}
+
+// InRange matches the numbers, of any numeric type, and the time.Time values
+// between min and max included.
+//
+//	Mock.On("Sleep", mock.InRange(time.Second, 2*time.Second))
+func InRange(min, max interface{}) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.InRange(%s, %s)", describe(min), describe(max)),
+		fn: func(actual interface{}) bool {
+			if t, ok := actual.(time.Time); ok {
+				minTime, minOK := min.(time.Time)
+				maxTime, maxOK := max.(time.Time)
+				return minOK && maxOK && !t.Before(minTime) && !t.After(maxTime)
+			}
+			n, ok := toFloat(actual)
+			minFloat, minOK := toFloat(min)
+			maxFloat, maxOK := toFloat(max)
+			return ok && minOK && maxOK && minFloat <= n && n <= maxFloat
+		},
+	}
+}
+
+// toFloat returns the value of the number x as a float64.
+func toFloat(x interface{}) (float64, bool) {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// Len matches the strings, slices, arrays, maps and channels whose length is
+// matched by length, an int or a matcher.
+//
+//	Mock.On("Save", mock.Len(mock.InRange(1, 10)))
+func Len(length interface{}) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.Len(%s)", describe(length)),
+		fn: func(actual interface{}) bool {
+			v := reflect.ValueOf(actual)
+			switch v.Kind() {
+			case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+				return matchArgument(length, v.Len())
+			}
+			return false
+		},
+	}
+}
+
+// HasField matches the structs, or pointers to structs, with an exported
+// field matched by expected, a value or a matcher. The field is named by
+// name, or by a path such as "Address.City" for a field of a field.
+//
+//	Mock.On("Save", mock.HasField("Name", "Ana"))
+func HasField(name string, expected interface{}) describedMatcher {
+	return describedMatcher{
+		description: fmt.Sprintf("mock.HasField(%q, %s)", name, describe(expected)),
+		fn: func(actual interface{}) bool {
+			v := reflect.ValueOf(actual)
+			for _, field := range strings.Split(name, ".") {
+				for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+					if v.IsNil() {
+						return false
+					}
+					v = v.Elem()
+				}
+				if v.Kind() != reflect.Struct {
+					return false
+				}
+				f, ok := v.Type().FieldByName(field)
+				if !ok || f.PkgPath != "" {
+					return false
+				}
+				v = v.FieldByIndex(f.Index)
+			}
+			return matchArgument(expected, v.Interface())
+		},
+	}
+}
+
+// ElementsMatch matches the slices and arrays whose elements are matched by
+// the elements of expected, a slice or an array of values or matchers,
+// ignoring their order.
+//
+//	Mock.On("Notify", mock.ElementsMatch([]string{"bob", "ana"}))
+func ElementsMatch(expected interface{}) describedMatcher {
+	e := reflect.ValueOf(expected)
+	if e.Kind() != reflect.Slice && e.Kind() != reflect.Array {
+		panic(fmt.Sprintf("mock: ElementsMatch takes a slice or an array, got %T", expected))
+	}
+	elements := make([]interface{}, e.Len())
+	for i := range elements {
+		elements[i] = e.Index(i).Interface()
+	}
+	return describedMatcher{
+		description: fmt.Sprintf("mock.ElementsMatch(%s)", describe(expected)),
+		fn: func(actual interface{}) bool {
+			v := reflect.ValueOf(actual)
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array || v.Len() != len(elements) {
+				return false
+			}
+			used := make([]bool, len(elements))
+		next:
+			for i := 0; i < v.Len(); i++ {
+				for j, element := range elements {
+					if !used[j] && matchArgument(element, v.Index(i).Interface()) {
+						used[j] = true
+						continue next
+					}
+				}
+				return false
+			}
+			return true
+		},
+	}
+}
+
+// JSONEq matches the strings and byte slices, of any string or []byte type,
+// holding JSON equivalent to expected.
+//
+//	Mock.On("Publish", mock.JSONEq(`{"id": 1, "name": "Ana"}`))
+func JSONEq(expected string) describedMatcher {
+	var expectedJSON interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedJSON); err != nil {
+		panic(fmt.Sprintf("mock: JSONEq takes valid JSON: %v", err))
+	}
+	return describedMatcher{
+		description: fmt.Sprintf("mock.JSONEq(%q)", expected),
+		fn: func(actual interface{}) bool {
+			var data []byte
+			v := reflect.ValueOf(actual)
+			switch {
+			case v.Kind() == reflect.String:
+				data = []byte(v.String())
+			case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+				data = v.Bytes()
+			default:
+				return false
+			}
+			var actualJSON interface{}
+			if err := json.Unmarshal(data, &actualJSON); err != nil {
+				return false
+			}
+			return reflect.DeepEqual(expectedJSON, actualJSON)
+		},
+	}
+}
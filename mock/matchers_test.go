@@ -0,0 +1,87 @@
+package mock
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type matcherAddress struct {
+	City string
+}
+
+type matcherUser struct {
+	Name    string
+	Address *matcherAddress
+	secret  string
+}
+
+type jsonBytes []byte
+
+func TestMatchers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	ana := &matcherUser{Name: "Ana", Address: &matcherAddress{City: "Lisbon"}, secret: "x"}
+	for _, c := range []struct {
+		matcher     describedMatcher
+		description string
+		matched     []interface{}
+		notMatched  []interface{}
+	}{
+		{Not(1), "mock.Not(1)", []interface{}{2, "1", nil}, []interface{}{1}},
+		{Not(Anything), "mock.Not(mock.Anything)", nil, []interface{}{1, nil}},
+		{And(Not(1), AnythingOfType("int")), `mock.And(mock.Not(1), mock.AnythingOfType("int"))`, []interface{}{2}, []interface{}{1, "2"}},
+		{Or("ana", IsType(0)), `mock.Or("ana", mock.IsType(int))`, []interface{}{"ana", 3}, []interface{}{"bob", 3.0}},
+		{Regex(`^\d+$`), `mock.Regex("^\\d+$")`, []interface{}{"123", []byte("4"), 56}, []interface{}{"a1", nil}},
+		{Regex(regexp.MustCompile(`a`)), `mock.Regex("a")`, []interface{}{"bar"}, []interface{}{"foo"}},
+		{Contains("an"), `mock.Contains("an")`, []interface{}{"Dana", []string{"an"}, map[string]int{"an": 1}}, []interface{}{"Bob", []string{"ana"}, 1}},
+		{Contains(Regex("^a")), `mock.Contains(mock.Regex("^a"))`, []interface{}{[2]string{"bob", "ana"}}, []interface{}{[]string{"bob"}}},
+		{HasPrefix("/users/"), `mock.HasPrefix("/users/")`, []interface{}{"/users/1"}, []interface{}{"/groups/1", []byte("/users/1")}},
+		{InRange(1, 10), "mock.InRange(1, 10)", []interface{}{1, uint8(5), 10.0}, []interface{}{0, 10.5, "5", nil}},
+		{InRange(time.Second, 2*time.Second), "mock.InRange(1000000000, 2000000000)", []interface{}{1500 * time.Millisecond}, []interface{}{time.Minute}},
+		{InRange(now, now.Add(time.Hour)), "", []interface{}{now.Add(time.Minute)}, []interface{}{now.Add(-time.Minute), 1}},
+		{Len(2), "mock.Len(2)", []interface{}{"ab", []int{1, 2}, map[int]int{1: 1, 2: 2}, [2]bool{}}, []interface{}{"abc", 2, nil}},
+		{Len(InRange(1, 3)), "mock.Len(mock.InRange(1, 3))", []interface{}{"abc"}, []interface{}{""}},
+		{HasField("Name", "Ana"), `mock.HasField("Name", "Ana")`, []interface{}{ana, *ana}, []interface{}{&matcherUser{Name: "Bob"}, (*matcherUser)(nil), "Ana"}},
+		{HasField("Address.City", HasPrefix("L")), `mock.HasField("Address.City", mock.HasPrefix("L"))`, []interface{}{ana}, []interface{}{&matcherUser{}}},
+		{HasField("secret", "x"), `mock.HasField("secret", "x")`, nil, []interface{}{ana}},
+		{HasField("Age", Anything), `mock.HasField("Age", mock.Anything)`, nil, []interface{}{ana}},
+		{ElementsMatch([]interface{}{1, Anything}), `mock.ElementsMatch([]interface {}{1, "mock.Anything"})`, []interface{}{[]int{2, 1}, []interface{}{1, 1}}, []interface{}{[]int{2, 3}, []int{1}, 1}},
+		{ElementsMatch([]string{"a", "b", "a"}), `mock.ElementsMatch([]string{"a", "b", "a"})`, []interface{}{[3]string{"a", "a", "b"}}, []interface{}{[]string{"a", "b", "b"}}},
+		{JSONEq(`{"id": 1, "tags": ["a"]}`), `mock.JSONEq("{\"id\": 1, \"tags\": [\"a\"]}")`, []interface{}{`{"tags":["a"],"id":1.0}`, []byte(` {"id":1,"tags":["a"]}`), jsonBytes(`{"id":1,"tags":["a"]}`)}, []interface{}{`{"id":1}`, `{`, 1}},
+	} {
+		if c.description != "" {
+			assert.Equal(t, c.description, c.matcher.String())
+		}
+		for _, actual := range c.matched {
+			assert.True(t, c.matcher.Matches(actual), "%s should match %#v", c.matcher, actual)
+		}
+		for _, actual := range c.notMatched {
+			assert.False(t, c.matcher.Matches(actual), "%s should not match %#v", c.matcher, actual)
+		}
+	}
+
+	assert.PanicsWithValue(t, "mock: ElementsMatch takes a slice or an array, got int", func() {
+		ElementsMatch(1)
+	})
+	assert.Panics(t, func() {
+		JSONEq(`{`)
+	})
+}
+
+func TestMatchersDiff(t *testing.T) {
+	t.Parallel()
+
+	m := new(TestExampleImplementation)
+	m.On("TheExampleMethod", InRange(1, 3), Not(0), Or(1, 2)).Return(1, nil)
+	result, _ := m.TheExampleMethod(2, 5, 1)
+	assert.Equal(t, 1, result)
+
+	diff, count := Arguments{InRange(1, 3), Not(0)}.Diff([]interface{}{4, 0})
+	assert.Equal(t, 2, count)
+	assert.Contains(t, diff, "\t0: FAIL:  (int=4) not matched by mock.InRange(1, 3)\n")
+	assert.Contains(t, diff, "\t1: FAIL:  (int=0) not matched by mock.Not(0)\n")
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/objx"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 	"github.com/stretchr/testify/internal/difflib"
 	"github.com/stretchr/testify/internal/spew"
 )
@@ -71,6 +72,16 @@ type Call struct {
 	// decoders.
 	RunFn func(Arguments)
 
+	// returnFn computes the return arguments from the arguments of each
+	// call, if set with ReturnFn, or the reason they cannot be passed to it.
+	returnFn func(Arguments) (Arguments, string)
+
+	// sequence holds the return arguments of the successive calls, if set
+	// with ReturnSequence or Then, and sequenceCalls how many were returned.
+	sequence      []Arguments
+	sequenceCalls int
+	sequenceEnd   SequenceEnd
+
 	// PanicMsg holds msg to be used to mock panic on the function call
 	//  if the PanicMsg is set to a non nil string the function call will panic
 	// irrespective of other settings
@@ -106,14 +117,209 @@ func (c *Call) unlock() {
 //
 //	Mock.On("DoSomething").Return(errors.New("failed"))
 func (c *Call) Return(returnArguments ...interface{}) *Call {
+	c.lock()
+	c.ReturnArguments = returnArguments
+	c.returnFn = nil
+	c.sequence = nil
+	t, msg := c.Parent.strictT, c.Parent.checkReturn(c.Method, "Return", returnArguments)
+	c.unlock()
+
+	if msg != "" {
+		strictFail(t, msg)
+	}
+	return c
+}
+
+// SequenceEnd is what a call returning a sequence of values does once the
+// sequence is exhausted.
+type SequenceEnd int
+
+const (
+	// RepeatLast makes the calls after the end of the sequence return its
+	// last values. It is the default.
+	RepeatLast SequenceEnd = iota
+	// FailAfterLast makes the calls after the end of the sequence fail the
+	// test.
+	FailAfterLast
+	// PanicAfterLast makes the calls after the end of the sequence panic.
+	PanicAfterLast
+)
+
+// ReturnSequence specifies the return arguments of the successive calls: the
+// first call returns the first arguments, the second call the second ones,
+// and so on. The calls after the end of the sequence return its last
+// arguments, unless set otherwise with AfterSequence. The expectation is only
+// met once the whole sequence was returned.
+//
+//	Mock.On("Read").ReturnSequence(Arguments{"a", nil}, Arguments{"", io.EOF})
+func (c *Call) ReturnSequence(returns ...Arguments) *Call {
+	if len(returns) == 0 {
+		panic("mock: ReturnSequence takes at least one set of return arguments")
+	}
+
+	c.lock()
+	c.ReturnArguments = returns[0]
+	c.returnFn = nil
+	c.sequence = returns
+	var t TestingT
+	var msg string
+	for _, returnArguments := range returns {
+		if t, msg = c.Parent.strictT, c.Parent.checkReturn(c.Method, "ReturnSequence", returnArguments); msg != "" {
+			break
+		}
+	}
+	c.unlock()
+
+	if msg != "" {
+		strictFail(t, msg)
+	}
+	return c
+}
+
+// Then adds the return arguments of the next call to the sequence started
+// with Return, as ReturnSequence does.
+//
+//	Mock.On("Read").Return("a", nil).Then("b", nil).Then("", io.EOF)
+func (c *Call) Then(returnArguments ...interface{}) *Call {
+	c.lock()
+	if len(c.sequence) == 0 {
+		c.sequence = []Arguments{c.ReturnArguments}
+	}
+	c.sequence = append(c.sequence, returnArguments)
+	c.returnFn = nil
+	t, msg := c.Parent.strictT, c.Parent.checkReturn(c.Method, "Then", returnArguments)
+	c.unlock()
+
+	if msg != "" {
+		strictFail(t, msg)
+	}
+	return c
+}
+
+// AfterSequence sets what the calls after the end of the sequence set with
+// ReturnSequence or Then do. The arguments set with Return alone are a
+// sequence of one.
+//
+//	Mock.On("Next").Return(1).Then(2).AfterSequence(FailAfterLast)
+func (c *Call) AfterSequence(end SequenceEnd) *Call {
 	c.lock()
 	defer c.unlock()
+	if len(c.sequence) == 0 {
+		c.sequence = []Arguments{c.ReturnArguments}
+	}
+	c.sequenceEnd = end
+	return c
+}
 
-	c.ReturnArguments = returnArguments
+// ReturnFn sets a function computing the return arguments of each call from
+// its arguments, instead of the fixed values set with Return. fn is either a
+// func(Arguments) Arguments, or a function taking the arguments of the method
+// with their types and returning its results:
+//
+//	Mock.On("Add", Anything, Anything).ReturnFn(func(args Arguments) Arguments {
+//		return Arguments{args.Int(0) + args.Int(1), nil}
+//	})
+//	Mock.On("Add", Anything, Anything).ReturnFn(func(a, b int) (int, error) {
+//		return a + b, nil
+//	})
+//
+// fn is called after the function set with Run, and the return arguments it
+// computes are recorded with the call in Mock.Calls.
+//
+// ReturnFn panics if fn is not a function, or does not take as many
+// arguments as the expectation has. The mock fails if the arguments of a
+// call cannot be passed to fn, and the call then returns the zero values of
+// the results of fn. If the mock is strict, the parameters and results of fn
+// are checked against the ones of the method.
+func (c *Call) ReturnFn(fn interface{}) *Call {
+	returnFn, fnType := c.returnFunc(fn)
+
+	c.lock()
+	c.returnFn = returnFn
+	c.sequence = nil
+	var t TestingT
+	var msg string
+	if fnType != nil {
+		t, msg = c.Parent.strictT, c.Parent.checkReturnFn(c.Method, fnType)
+	}
+	c.unlock()
 
+	if msg != "" {
+		strictFail(t, msg)
+	}
 	return c
 }
 
+// returnFunc returns fn, given to ReturnFn, as the returnFn of c, and the
+// type of fn if it takes typed arguments.
+func (c *Call) returnFunc(fn interface{}) (func(Arguments) (Arguments, string), reflect.Type) {
+	if f, ok := fn.(func(Arguments) Arguments); ok {
+		return func(args Arguments) (Arguments, string) {
+			return f(args), ""
+		}, nil
+	}
+
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		panic(fmt.Sprintf("mock: ReturnFn takes a function, got %T", fn))
+	}
+	fnType := v.Type()
+	c.lock()
+	method, expected := c.Method, len(c.Arguments)
+	c.unlock()
+	if fnType.IsVariadic() && expected < fnType.NumIn()-1 || !fnType.IsVariadic() && expected != fnType.NumIn() {
+		panic(fmt.Sprintf("mock: ReturnFn of %s takes %d argument(s), but the expectation has %d", method, fnType.NumIn(), expected))
+	}
+
+	// zero returns the zero values of the results of fn, for the calls
+	// whose arguments cannot be passed to it.
+	zero := func(format string, args ...interface{}) (Arguments, string) {
+		results := make(Arguments, fnType.NumOut())
+		for i := range results {
+			results[i] = reflect.Zero(fnType.Out(i)).Interface()
+		}
+		return results, fmt.Sprintf(format, args...)
+	}
+
+	return func(args Arguments) (Arguments, string) {
+		// Variadic arguments are either passed one by one or as a slice
+		spread := fnType.IsVariadic() && len(args) == fnType.NumIn() &&
+			args[len(args)-1] != nil && reflect.TypeOf(args[len(args)-1]).AssignableTo(fnType.In(fnType.NumIn()-1))
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			var argType reflect.Type
+			switch {
+			case spread || i < fnType.NumIn()-1 || !fnType.IsVariadic() && i < fnType.NumIn():
+				argType = fnType.In(i)
+			case fnType.IsVariadic():
+				argType = fnType.In(fnType.NumIn() - 1).Elem()
+			default:
+				return zero("mock: ReturnFn of %s takes %d argument(s), but the call has %d", method, fnType.NumIn(), len(args))
+			}
+			if arg == nil {
+				in[i] = reflect.Zero(argType)
+				continue
+			}
+			in[i] = reflect.ValueOf(arg)
+			if !in[i].Type().AssignableTo(argType) {
+				return zero("mock: argument %d of %s is %T, which cannot be passed to ReturnFn as %s", i, method, arg, argType)
+			}
+		}
+
+		var out []reflect.Value
+		if spread {
+			out = v.CallSlice(in)
+		} else {
+			out = v.Call(in)
+		}
+		results := make(Arguments, len(out))
+		for i, r := range out {
+			results[i] = r.Interface()
+		}
+		return results, ""
+	}, fnType
+}
+
 // Panic specifies if the function call should fail and the panic message
 //
 //	Mock.On("DoSomething").Panic("test panic")
@@ -162,7 +368,8 @@ func (c *Call) WaitUntil(w <-chan time.Time) *Call {
 	return c
 }
 
-// After sets how long to block until the call returns
+// After sets how long to block until the call returns. The time is measured
+// with the clock set by Mock.SetClock, if any.
 //
 //	Mock.On("MyMethod", arg1, arg2).After(time.Second)
 func (c *Call) After(d time.Duration) *Call {
@@ -196,6 +403,29 @@ func (c *Call) Maybe() *Call {
 	return c
 }
 
+// MatchArgument replaces the expected argument at index with matcher, which
+// can be a value or a matcher such as Anything. It lets typed expectations,
+// whose arguments must have the types of the parameters, match an argument
+// loosely.
+//
+//	Mock.On("Get", ctx, 1).MatchArgument(0, Anything)
+func (c *Call) MatchArgument(index int, matcher interface{}) *Call {
+	if v := reflect.ValueOf(matcher); v.Kind() == reflect.Func {
+		panic(fmt.Sprintf("cannot use Func in expectations. Use mock.AnythingOfType(\"%T\")", matcher))
+	}
+
+	c.lock()
+	defer c.unlock()
+	if index < 0 || index >= len(c.Arguments) {
+		panic(fmt.Sprintf("mock: cannot match argument %d of %s, which has %d argument(s)", index, c.Method, len(c.Arguments)))
+	}
+	// The arguments may be a slice given to On, which is left untouched
+	arguments := append(Arguments(nil), c.Arguments...)
+	arguments[index] = matcher
+	c.Arguments = arguments
+	return c
+}
+
 // On chains a new expectation description onto the mocked interface. This
 // allows syntax like.
 //
@@ -313,6 +543,15 @@ type Mock struct {
 	// this data completely allowing you to do whatever you like with it.
 	testData objx.Map
 
+	// clock is used to wait for the duration set with Call.After. nil means
+	// the real time is used.
+	clock clock.Clock
+
+	// strictT and strictType are set by Strict, to check the expectations
+	// against the methods of the mocked type.
+	strictT    TestingT
+	strictType reflect.Type
+
 	mutex sync.Mutex
 }
 
@@ -348,6 +587,124 @@ func (m *Mock) Test(t TestingT) {
 	m.test = t
 }
 
+// Strict makes the mock check its expectations against the methods of impl,
+// the mocked type embedding it, and report the mistakes to t as they are set:
+// On must name a method of impl with as many arguments as it takes, and the
+// values given to Return must be assignable to its results.
+//
+//	func NewMockedService(t *testing.T) *MockedService {
+//		m := new(MockedService)
+//		m.Mock.Strict(t, m)
+//		return m
+//	}
+func (m *Mock) Strict(t TestingT, impl interface{}) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.strictT = t
+	m.strictType = reflect.TypeOf(impl)
+}
+
+// checkOn returns the mistake in an expectation of method with arguments,
+// if the mock is strict. It must be called with the mutex held.
+func (m *Mock) checkOn(method string, arguments Arguments) string {
+	if m.strictType == nil {
+		return ""
+	}
+	meth, ok := m.strictType.MethodByName(method)
+	if !ok {
+		return fmt.Sprintf("mock: %s has no method %s", m.strictType, method)
+	}
+	// The receiver is the first input of meth.Type
+	in := meth.Type.NumIn() - 1
+	switch {
+	case meth.Type.IsVariadic() && len(arguments) < in-1:
+		return fmt.Sprintf("mock: %s.%s takes at least %d argument(s), but the expectation has %d", m.strictType, method, in-1, len(arguments))
+	case !meth.Type.IsVariadic() && len(arguments) != in:
+		return fmt.Sprintf("mock: %s.%s takes %d argument(s), but the expectation has %d", m.strictType, method, in, len(arguments))
+	}
+	return ""
+}
+
+// checkReturn returns the mistake in the values returned by method, set with
+// the function source, if the mock is strict. It must be called with the
+// mutex held.
+func (m *Mock) checkReturn(method, source string, returnArguments Arguments) string {
+	if m.strictType == nil {
+		return ""
+	}
+	meth, ok := m.strictType.MethodByName(method)
+	if !ok {
+		// Already reported by On
+		return ""
+	}
+	if out := meth.Type.NumOut(); len(returnArguments) != out {
+		return fmt.Sprintf("mock: %s.%s returns %d value(s), but %s was given %d", m.strictType, method, out, source, len(returnArguments))
+	}
+	for i, value := range returnArguments {
+		expected := meth.Type.Out(i)
+		if value == nil {
+			switch expected.Kind() {
+			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+				continue
+			}
+			return fmt.Sprintf("mock: Return value %d of %s.%s is nil, which cannot be used as %s", i, m.strictType, method, expected)
+		}
+		if !reflect.TypeOf(value).AssignableTo(expected) {
+			return fmt.Sprintf("mock: Return value %d of %s.%s is %T, which cannot be used as %s", i, m.strictType, method, value, expected)
+		}
+	}
+	return ""
+}
+
+// checkReturnFn returns the mistake in fnType, the type of a function given
+// to ReturnFn for method, if the mock is strict. It must be called with the
+// mutex held.
+func (m *Mock) checkReturnFn(method string, fnType reflect.Type) string {
+	if m.strictType == nil {
+		return ""
+	}
+	meth, ok := m.strictType.MethodByName(method)
+	if !ok {
+		// Already reported by On
+		return ""
+	}
+	// The receiver is the first input of meth.Type
+	if in := meth.Type.NumIn() - 1; fnType.NumIn() != in || fnType.IsVariadic() != meth.Type.IsVariadic() {
+		return fmt.Sprintf("mock: ReturnFn of %s.%s is %s, which does not take the arguments of %s", m.strictType, method, fnType, meth.Type)
+	}
+	for i := 0; i < fnType.NumIn(); i++ {
+		if !meth.Type.In(i + 1).AssignableTo(fnType.In(i)) {
+			return fmt.Sprintf("mock: ReturnFn of %s.%s takes %s as argument %d, but the method takes %s", m.strictType, method, fnType.In(i), i, meth.Type.In(i+1))
+		}
+	}
+	if out := meth.Type.NumOut(); fnType.NumOut() != out {
+		return fmt.Sprintf("mock: %s.%s returns %d value(s), but ReturnFn was given a function returning %d", m.strictType, method, out, fnType.NumOut())
+	}
+	for i := 0; i < fnType.NumOut(); i++ {
+		if !fnType.Out(i).AssignableTo(meth.Type.Out(i)) {
+			return fmt.Sprintf("mock: Return value %d of %s.%s is %s, which cannot be used as %s", i, m.strictType, method, fnType.Out(i), meth.Type.Out(i))
+		}
+	}
+	return ""
+}
+
+// strictFail reports the mistake msg found by checkOn or checkReturn to t.
+func strictFail(t TestingT, msg string) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	assert.Fail(t, msg)
+}
+
+// SetClock sets the [clock.Clock] used to wait for the durations set with
+// Call.After, instead of the real time. With a [clock.Fake], calls return once
+// the fake clock has been advanced.
+func (m *Mock) SetClock(c clock.Clock) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.clock = c
+}
+
 // fail fails the current test with the given formatted format and args.
 // In case that a test was defined, it uses the test APIs for failing a test,
 // otherwise it uses panic.
@@ -374,10 +731,14 @@ func (m *Mock) On(methodName string, arguments ...interface{}) *Call {
 	}
 
 	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
 	c := newCall(m, methodName, assert.CallerInfo(), arguments, make([]interface{}, 0))
 	m.ExpectedCalls = append(m.ExpectedCalls, c)
+	t, msg := m.strictT, m.checkOn(methodName, arguments)
+	m.mutex.Unlock()
+
+	if msg != "" {
+		strictFail(t, msg)
+	}
 	return c
 }
 
@@ -554,13 +915,46 @@ func (m *Mock) MethodCalled(methodName string, arguments ...interface{}) Argumen
 	}
 	call.totalCalls++
 
+	sequenced := len(call.sequence) > 0
+	var sequenceArgs Arguments
+	if sequenced {
+		if call.sequenceCalls >= len(call.sequence) && call.sequenceEnd != RepeatLast {
+			msg := fmt.Sprintf("mock: %s was called %d time(s), but its return sequence has %d value(s)\n\tThis call was unexpected:\n\t\t%s\n\tat: %s",
+				methodName, call.totalCalls, len(call.sequence), callString(methodName, arguments, true), assert.CallerInfo())
+			m.mutex.Unlock()
+			if call.sequenceEnd == PanicAfterLast {
+				panic(msg)
+			}
+			m.fail("\n%s", msg)
+			return nil
+		}
+		i := call.sequenceCalls
+		if i >= len(call.sequence) {
+			i = len(call.sequence) - 1
+		}
+		sequenceArgs = call.sequence[i]
+		call.sequenceCalls++
+	} else {
+		sequenceArgs = call.ReturnArguments
+	}
+
+	for i, expected := range call.Arguments {
+		if captor, ok := expected.(*ArgumentCaptor); ok && i < len(arguments) {
+			captor.capture(arguments[i])
+		}
+	}
+
 	// add the call
-	m.Calls = append(m.Calls, *newCall(m, methodName, assert.CallerInfo(), arguments, call.ReturnArguments))
+	m.Calls = append(m.Calls, *newCall(m, methodName, assert.CallerInfo(), arguments, sequenceArgs))
+	recorded := len(m.Calls) - 1
+	clk := m.clock
 	m.mutex.Unlock()
 
 	// block if specified
 	if call.WaitFor != nil {
 		<-call.WaitFor
+	} else if clk != nil && call.waitTime > 0 {
+		<-clk.NewTimer(call.waitTime).C()
 	} else {
 		time.Sleep(call.waitTime)
 	}
@@ -582,8 +976,33 @@ func (m *Mock) MethodCalled(methodName string, arguments ...interface{}) Argumen
 
 	m.mutex.Lock()
 	returnArgs := call.ReturnArguments
+	if sequenced {
+		returnArgs = sequenceArgs
+	}
+	returnFn := call.returnFn
 	m.mutex.Unlock()
 
+	if returnFn != nil {
+		var msg string
+		returnArgs, msg = returnFn(arguments)
+
+		m.mutex.Lock()
+		if recorded < len(m.Calls) {
+			m.Calls[recorded].ReturnArguments = returnArgs
+		}
+		t, strictMsg := m.strictT, ""
+		if msg == "" {
+			strictMsg = m.checkReturn(methodName, "ReturnFn", returnArgs)
+		}
+		m.mutex.Unlock()
+		if msg != "" {
+			m.fail("%s", msg)
+		}
+		if strictMsg != "" {
+			strictFail(t, strictMsg)
+		}
+	}
+
 	return returnArgs
 }
 
@@ -656,6 +1075,9 @@ func (m *Mock) checkExpectation(call *Call) (bool, string) {
 	if call.Repeatability > 0 {
 		return false, fmt.Sprintf("FAIL:\t%s(%s)\n\t\tat: %s", call.Method, call.Arguments.String(), call.callerInfo)
 	}
+	if !call.optional && call.sequenceCalls < len(call.sequence) {
+		return false, fmt.Sprintf("FAIL:\t%s(%s)\n\t\tat: %s\n\t\treturned %d of the %d values of its sequence", call.Method, call.Arguments.String(), call.callerInfo, call.sequenceCalls, len(call.sequence))
+	}
 	return true, fmt.Sprintf("PASS:\t%s(%s)", call.Method, call.Arguments.String())
 }
 
@@ -870,6 +1292,12 @@ func FunctionalOptions(values ...interface{}) *FunctionalOptionsArgument {
 	}
 }
 
+// matcher is an expected argument that matches the actual ones itself.
+type matcher interface {
+	Matches(argument interface{}) bool
+	String() string
+}
+
 // argumentMatcher performs custom argument matching, returning whether or
 // not the argument is matched by the expectation fixture function.
 type argumentMatcher struct {
@@ -988,7 +1416,7 @@ func (args Arguments) Diff(objects []interface{}) (string, int) {
 			expectedFmt = fmt.Sprintf("(%[1]T=%[1]v)", expected)
 		}
 
-		if matcher, ok := expected.(argumentMatcher); ok {
+		if matcher, ok := expected.(matcher); ok {
 			var matches bool
 			func() {
 				defer func() {
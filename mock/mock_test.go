@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -558,6 +559,27 @@ func Test_Mock_On_WithFuncTypeArg(t *testing.T) {
 	})
 }
 
+func Test_Mock_On_MatchArgument(t *testing.T) {
+	t.Parallel()
+
+	var mockedService = new(TestExampleImplementation)
+
+	arguments := []interface{}{1, 2, 3}
+	c := mockedService.On("TheExampleMethod", arguments...).MatchArgument(1, Anything).Return(5, nil)
+	assert.Equal(t, Arguments{1, Anything, 3}, c.Arguments)
+	assert.Equal(t, []interface{}{1, 2, 3}, arguments)
+
+	result, _ := mockedService.TheExampleMethod(1, 7, 3)
+	assert.Equal(t, 5, result)
+
+	assert.Panics(t, func() {
+		c.MatchArgument(3, Anything)
+	})
+	assert.Panics(t, func() {
+		c.MatchArgument(0, func(int) bool { return true })
+	})
+}
+
 func Test_Mock_Unset(t *testing.T) {
 	t.Parallel()
 
@@ -829,6 +851,30 @@ func Test_Mock_Return_After(t *testing.T) {
 
 }
 
+func Test_Mock_Return_After_Clock(t *testing.T) {
+	t.Parallel()
+
+	var mockedService = new(TestExampleImplementation)
+	fake := clock.NewFake(time.Now())
+	mockedService.Mock.SetClock(fake)
+
+	mockedService.Mock.On("asyncCall", 1, 2, 3).Return(5, "6", true).After(time.Hour)
+
+	ch := make(chan Arguments)
+	go asyncCall(&mockedService.Mock, ch)
+
+	fake.WaitForTimers(1)
+	select {
+	case <-ch:
+		t.Fatal("should have waited")
+	default:
+	}
+
+	fake.Advance(time.Hour)
+	returnArguments := <-ch
+	assert.Equal(t, Arguments{5, "6", true}, returnArguments)
+}
+
 func Test_Mock_Return_Run(t *testing.T) {
 	t.Parallel()
 
@@ -2467,3 +2513,208 @@ func TestIssue1227AssertExpectationsForObjectsWithMock(t *testing.T) {
 	AssertExpectationsForObjects(mockT, Mock{})
 	assert.Equal(t, 1, mockT.errorfCount)
 }
+
+func TestMockStrict(t *testing.T) {
+	t.Parallel()
+
+	m := new(TestExampleImplementation)
+	tcl := &tCustomLogger{t, []string{}, []string{}}
+	m.Strict(tcl, m)
+
+	m.On("TheExampleMethod", 1, 2, Anything).Return(3, nil)
+	m.On("TheExampleMethodVariadic").Return(errors.New("failed"))
+	m.On("TheExampleMethodVariadic", 1, 2, 3).Return(nil)
+	m.On("TheExampleMethod3", AnythingOfType("*mock.ExampleType")).Return(nil)
+	m.On("TheExampleMethod2", true).Return()
+	assert.Empty(t, tcl.errs)
+
+	m.On("TheExampleMethodd", 1, 2, 3)
+	m.On("TheExampleMethod", 1, 2)
+	m.On("TheExampleMethodFunctionalOptions")
+	m.On("TheExampleMethod", 1, 2, 3).Return(3)
+	m.On("TheExampleMethod", 1, 2, 3).Return("3", nil)
+	m.On("TheExampleMethod", 1, 2, 3).Return(nil, nil)
+	m.On("TheExampleMethod3", nil).Return(42)
+
+	expected := []string{
+		"mock: *mock.TestExampleImplementation has no method TheExampleMethodd",
+		"mock: *mock.TestExampleImplementation.TheExampleMethod takes 3 argument(s), but the expectation has 2",
+		"mock: *mock.TestExampleImplementation.TheExampleMethodFunctionalOptions takes at least 1 argument(s), but the expectation has 0",
+		"mock: *mock.TestExampleImplementation.TheExampleMethod returns 2 value(s), but Return was given 1",
+		"mock: Return value 0 of *mock.TestExampleImplementation.TheExampleMethod is string, which cannot be used as int",
+		"mock: Return value 0 of *mock.TestExampleImplementation.TheExampleMethod is nil, which cannot be used as int",
+		"mock: Return value 0 of *mock.TestExampleImplementation.TheExampleMethod3 is int, which cannot be used as error",
+	}
+	require.Len(t, tcl.errs, len(expected))
+	for i, msg := range expected {
+		assert.Contains(t, tcl.errs[i], msg)
+	}
+
+	// Not strict by default
+	m = new(TestExampleImplementation)
+	m.Test(tcl)
+	m.On("TheExampleMethodd").Return(1, 2, 3)
+	assert.Len(t, tcl.errs, len(expected))
+}
+
+func TestCallReturnFn(t *testing.T) {
+	t.Parallel()
+
+	m := new(TestExampleImplementation)
+	m.On("TheExampleMethod", Anything, Anything, Anything).ReturnFn(func(args Arguments) Arguments {
+		return Arguments{args.Int(0) + args.Int(1) + args.Int(2), nil}
+	})
+	m.On("TheExampleMethodMixedVariadic", 1, Anything).ReturnFn(func(a int, b ...int) error {
+		if len(b) != a {
+			return fmt.Errorf("%d argument(s)", len(b))
+		}
+		return nil
+	})
+
+	result, _ := m.TheExampleMethod(1, 2, 3)
+	assert.Equal(t, 6, result)
+	result, _ = m.TheExampleMethod(4, 5, 6)
+	assert.Equal(t, 15, result)
+	assert.NoError(t, m.TheExampleMethodMixedVariadic(1, 5))
+	assert.EqualError(t, m.TheExampleMethodMixedVariadic(1), "0 argument(s)")
+
+	// The calls record the computed return arguments
+	assert.Equal(t, Arguments{6, nil}, m.Calls[0].ReturnArguments)
+	assert.Equal(t, Arguments{15, nil}, m.Calls[1].ReturnArguments)
+
+	// The variadic arguments can also be given one by one
+	m.On("TheExampleMethodVariadic", Anything, Anything).ReturnFn(func(a ...int) error {
+		return fmt.Errorf("%v", a)
+	})
+	assert.EqualError(t, m.MethodCalled("TheExampleMethodVariadic", 1, 2).Error(0), "[1 2]")
+
+	// Return replaces ReturnFn
+	m.On("TheExampleMethod3", Anything).ReturnFn(func(*ExampleType) error { return errors.New("failed") }).Return(nil)
+	assert.NoError(t, m.TheExampleMethod3(nil))
+
+	tcl := &tCustomLogger{t, []string{}, []string{}}
+	m.Test(tcl)
+	m.On("TheExampleMethod2", Anything).ReturnFn(func(string) {})
+	m.TheExampleMethod2(true)
+	require.Len(t, tcl.errs, 1)
+	assert.Equal(t, "mock: argument 0 of TheExampleMethod2 is bool, which cannot be passed to ReturnFn as string", tcl.errs[0])
+
+	// The results of a call that cannot be passed to fn are zero values
+	m = new(TestExampleImplementation)
+	m.Test(tcl)
+	m.On("TheExampleMethod", Anything, Anything, Anything).ReturnFn(func(a, b int, c string) (int, error) { return a, nil })
+	result, _ = m.TheExampleMethod(7, 8, 9)
+	assert.Equal(t, 0, result)
+	assert.Equal(t, Arguments{0, nil}, m.Calls[0].ReturnArguments)
+	require.Len(t, tcl.errs, 2)
+	assert.Equal(t, "mock: argument 2 of TheExampleMethod is int, which cannot be passed to ReturnFn as string", tcl.errs[1])
+
+	assert.PanicsWithValue(t, "mock: ReturnFn takes a function, got int", func() {
+		m.On("TheExampleMethod2", true).ReturnFn(42)
+	})
+	assert.PanicsWithValue(t, "mock: ReturnFn of TheExampleMethod takes 1 argument(s), but the expectation has 3", func() {
+		m.On("TheExampleMethod", 1, 2, 3).ReturnFn(func(int) (int, error) { return 0, nil })
+	})
+
+	// fn can call the mock, and a panic in fn leaves the mock usable
+	m = new(TestExampleImplementation)
+	m.On("TheExampleMethod2", true).ReturnFn(func(bool) { m.AssertCalled(t, "TheExampleMethod2", true) })
+	m.TheExampleMethod2(true)
+	m.On("TheExampleMethod2", false).ReturnFn(func(bool) { panic("fn failed") })
+	assert.PanicsWithValue(t, "fn failed", func() { m.TheExampleMethod2(false) })
+	m.AssertCalled(t, "TheExampleMethod2", false)
+}
+
+func TestCallReturnFnStrict(t *testing.T) {
+	t.Parallel()
+
+	m := new(TestExampleImplementation)
+	tcl := &tCustomLogger{t, []string{}, []string{}}
+	m.Strict(tcl, m)
+
+	m.On("TheExampleMethod", Anything, Anything, Anything).ReturnFn(func(a, b, c int) (int, error) { return a, nil })
+	m.On("TheExampleMethodVariadic", Anything).ReturnFn(func(a ...int) error { return nil })
+	assert.Empty(t, tcl.errs)
+
+	m.On("TheExampleMethod", 1, 2, 3).ReturnFn(func(a, b int, c string) (int, error) { return a, nil })
+	m.On("TheExampleMethod", 1, 2, 3).ReturnFn(func(a, b, c int) int { return a })
+	m.On("TheExampleMethod", 1, 2, 3).ReturnFn(func(a, b, c int) (string, error) { return "", nil })
+	m.On("TheExampleMethodVariadic", Anything).ReturnFn(func(a []int) error { return nil })
+	m.On("TheExampleMethod4", Anything).ReturnFn(func(Arguments) Arguments { return Arguments{nil, nil} })
+	_ = m.TheExampleMethod4(nil)
+
+	expected := []string{
+		"mock: ReturnFn of *mock.TestExampleImplementation.TheExampleMethod takes string as argument 2, but the method takes int",
+		"mock: *mock.TestExampleImplementation.TheExampleMethod returns 2 value(s), but ReturnFn was given a function returning 1",
+		"mock: Return value 0 of *mock.TestExampleImplementation.TheExampleMethod is string, which cannot be used as int",
+		"mock: ReturnFn of *mock.TestExampleImplementation.TheExampleMethodVariadic is func([]int) error, which does not take the arguments of func(*mock.TestExampleImplementation, ...int) error",
+		"mock: *mock.TestExampleImplementation.TheExampleMethod4 returns 1 value(s), but ReturnFn was given 2",
+	}
+	require.Len(t, tcl.errs, len(expected))
+	for i, msg := range expected {
+		assert.Contains(t, tcl.errs[i], msg)
+	}
+}
+
+func TestCallReturnSequence(t *testing.T) {
+	t.Parallel()
+
+	m := new(timer)
+	m.On("GetTime", 1).ReturnSequence(Arguments{"a"}, Arguments{"b"})
+	m.On("GetTime", 2).Return("x").Then("y").Then("z")
+
+	assert.Equal(t, "a", m.GetTime(1))
+	assert.Equal(t, "x", m.GetTime(2))
+	assert.Equal(t, "b", m.GetTime(1))
+	assert.Equal(t, "b", m.GetTime(1))
+	assert.Equal(t, "y", m.GetTime(2))
+
+	tcl := &tCustomLogger{t, []string{}, []string{}}
+	assert.False(t, m.AssertExpectations(tcl))
+	require.Len(t, tcl.logs, 1)
+	assert.Contains(t, tcl.logs[0], "FAIL:\tGetTime(int)\n")
+	assert.Contains(t, tcl.logs[0], "\n\t\treturned 2 of the 3 values of its sequence")
+
+	assert.Equal(t, "z", m.GetTime(2))
+	assert.True(t, m.AssertExpectations(t))
+	assert.Equal(t, Arguments{"z"}, m.Calls[len(m.Calls)-1].ReturnArguments)
+
+	// Return starts over
+	m.On("GetTime", 3).Return("a").Then("b").Return("c")
+	assert.Equal(t, "c", m.GetTime(3))
+	assert.Equal(t, "c", m.GetTime(3))
+
+	assert.PanicsWithValue(t, "mock: ReturnSequence takes at least one set of return arguments", func() {
+		m.On("GetTime", 4).ReturnSequence()
+	})
+}
+
+func TestCallReturnSequenceEnd(t *testing.T) {
+	t.Parallel()
+
+	m := new(timer)
+	m.On("GetTime", 1).Return("a").AfterSequence(PanicAfterLast)
+	assert.Equal(t, "a", m.GetTime(1))
+	func() {
+		defer func() {
+			assert.Contains(t, recover(), "mock: GetTime was called 2 time(s), but its return sequence has 1 value(s)\n\tThis call was unexpected:\n\t\tGetTime(int)\n\t\t0: 1\n\tat: ")
+		}()
+		m.GetTime(1)
+	}()
+
+	tcl := &tCustomLogger{t, []string{}, []string{}}
+	m.Test(tcl)
+	m.On("GetTime", 2).ReturnSequence(Arguments{"a"}).AfterSequence(FailAfterLast)
+	m.MethodCalled("GetTime", 2)
+	assert.Nil(t, m.MethodCalled("GetTime", 2))
+	require.Len(t, tcl.errs, 1)
+	assert.Contains(t, tcl.errs[0], "mock: GetTime was called 2 time(s), but its return sequence has 1 value(s)")
+
+	m = new(timer)
+	m.Strict(tcl, m)
+	m.On("GetTime", 3).Return("a").Then(3)
+	m.On("GetTime", 3).ReturnSequence(Arguments{"a"}, Arguments{})
+	require.Len(t, tcl.errs, 3)
+	assert.Contains(t, tcl.errs[1], "mock: Return value 0 of *mock.timer.GetTime is int, which cannot be used as string")
+	assert.Contains(t, tcl.errs[2], "mock: *mock.timer.GetTime returns 1 value(s), but ReturnSequence was given 0")
+}
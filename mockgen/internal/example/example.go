@@ -0,0 +1,34 @@
+// Package example has interfaces to generate mocks for, to test mockgen.
+package example
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate go run github.com/stretchr/testify/mockgen -type Repository,Clock -expecter -typed -out example_mock.go
+
+// User is stored in a Repository.
+type User struct {
+	ID   int
+	Name string
+}
+
+// Option changes how a Repository finds users.
+type Option func(*time.Duration)
+
+// Repository stores users.
+type Repository interface {
+	io.Closer
+	Get(ctx context.Context, id int) (*User, error)
+	Find(ctx context.Context, name string, opts ...Option) ([]*User, error)
+	Put(context.Context, *User) error
+	Watch(func(User)) (<-chan User, map[int]*User)
+}
+
+// Clock has a method without parameters or results.
+type Clock interface {
+	Now() time.Time
+	Tick()
+}
@@ -0,0 +1,513 @@
+// Code generated with github.com/stretchr/testify/mockgen; DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a mock implementation of Repository, built on mock.Mock.
+type MockRepository struct {
+	mock.Mock
+}
+
+// NewMockRepository returns a new MockRepository, whose expectations are asserted when the test ends.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	m := &MockRepository{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.Mock.AssertExpectations(t) })
+	return m
+}
+
+// Close provides a mock implementation of Repository.Close.
+func (_m *MockRepository) Close() error {
+	_args := _m.Mock.MethodCalled("Close")
+	return _args.Error(0)
+}
+
+// Find provides a mock implementation of Repository.Find.
+func (_m *MockRepository) Find(ctx context.Context, name string, opts ...Option) ([]*User, error) {
+	_ca := []interface{}{ctx, name}
+	for _, _v := range opts {
+		_ca = append(_ca, _v)
+	}
+	_args := _m.Mock.MethodCalled("Find", _ca...)
+	_r0, _ok := _args.Get(0).([]*User)
+	if !_ok && _args.Get(0) != nil {
+		panic(fmt.Sprintf("mock: MockRepository.Find: result 0 should be a []*User, got %#v", _args.Get(0)))
+	}
+	return _r0, _args.Error(1)
+}
+
+// Get provides a mock implementation of Repository.Get.
+func (_m *MockRepository) Get(ctx context.Context, id int) (*User, error) {
+	_args := _m.Mock.MethodCalled("Get", ctx, id)
+	_r0, _ok := _args.Get(0).(*User)
+	if !_ok && _args.Get(0) != nil {
+		panic(fmt.Sprintf("mock: MockRepository.Get: result 0 should be a *User, got %#v", _args.Get(0)))
+	}
+	return _r0, _args.Error(1)
+}
+
+// Put provides a mock implementation of Repository.Put.
+func (_m *MockRepository) Put(_a0 context.Context, _a1 *User) error {
+	_args := _m.Mock.MethodCalled("Put", _a0, _a1)
+	return _args.Error(0)
+}
+
+// Watch provides a mock implementation of Repository.Watch.
+func (_m *MockRepository) Watch(_a0 func(User)) (<-chan User, map[int]*User) {
+	_args := _m.Mock.MethodCalled("Watch", _a0)
+	_r0, _ok := _args.Get(0).(<-chan User)
+	if !_ok && _args.Get(0) != nil {
+		panic(fmt.Sprintf("mock: MockRepository.Watch: result 0 should be a <-chan User, got %#v", _args.Get(0)))
+	}
+	_r1, _ok := _args.Get(1).(map[int]*User)
+	if !_ok && _args.Get(1) != nil {
+		panic(fmt.Sprintf("mock: MockRepository.Watch: result 1 should be a map[int]*User, got %#v", _args.Get(1)))
+	}
+	return _r0, _r1
+}
+
+// OnClose expects a call to Close with the arguments given.
+func (_m *MockRepository) OnClose() *MockRepository_Close_Call {
+	return &MockRepository_Close_Call{Call: _m.Mock.On("Close")}
+}
+
+// OnFind expects a call to Find with the arguments given. Use MatchArgument
+// to match an argument with a matcher such as mock.Anything.
+func (_m *MockRepository) OnFind(ctx context.Context, name string, opts ...Option) *MockRepository_Find_Call {
+	_ca := []interface{}{ctx, name}
+	for _, _v := range opts {
+		_ca = append(_ca, mock.IsType(_v))
+	}
+	return &MockRepository_Find_Call{Call: _m.Mock.On("Find", _ca...)}
+}
+
+// OnGet expects a call to Get with the arguments given. Use MatchArgument
+// to match an argument with a matcher such as mock.Anything.
+func (_m *MockRepository) OnGet(ctx context.Context, id int) *MockRepository_Get_Call {
+	return &MockRepository_Get_Call{Call: _m.Mock.On("Get", ctx, id)}
+}
+
+// OnPut expects a call to Put with the arguments given. Use MatchArgument
+// to match an argument with a matcher such as mock.Anything.
+func (_m *MockRepository) OnPut(_a0 context.Context, _a1 *User) *MockRepository_Put_Call {
+	return &MockRepository_Put_Call{Call: _m.Mock.On("Put", _a0, _a1)}
+}
+
+// OnWatch expects a call to Watch with the arguments given. Use MatchArgument
+// to match an argument with a matcher such as mock.Anything.
+func (_m *MockRepository) OnWatch(_a0 func(User)) *MockRepository_Watch_Call {
+	return &MockRepository_Watch_Call{Call: _m.Mock.On("Watch", mock.IsType(_a0))}
+}
+
+// MockRepository_Expecter sets the expectations of a MockRepository with typed helpers.
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+// EXPECT returns the typed expectation helpers of the mock.
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// Close expects a call to Close.
+func (_e *MockRepository_Expecter) Close() *MockRepository_Close_Call {
+	return &MockRepository_Close_Call{Call: _e.mock.On("Close")}
+}
+
+// Find expects a call to Find with arguments matching the ones given, which
+// can be values or matchers such as mock.Anything.
+func (_e *MockRepository_Expecter) Find(ctx interface{}, name interface{}, opts ...interface{}) *MockRepository_Find_Call {
+	_ca := []interface{}{ctx, name}
+	for _, _v := range opts {
+		_ca = append(_ca, _v)
+	}
+	return &MockRepository_Find_Call{Call: _e.mock.On("Find", _ca...)}
+}
+
+// Get expects a call to Get with arguments matching the ones given, which
+// can be values or matchers such as mock.Anything.
+func (_e *MockRepository_Expecter) Get(ctx interface{}, id interface{}) *MockRepository_Get_Call {
+	return &MockRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+// Put expects a call to Put with arguments matching the ones given, which
+// can be values or matchers such as mock.Anything.
+func (_e *MockRepository_Expecter) Put(_a0 interface{}, _a1 interface{}) *MockRepository_Put_Call {
+	return &MockRepository_Put_Call{Call: _e.mock.On("Put", _a0, _a1)}
+}
+
+// Watch expects a call to Watch with arguments matching the ones given, which
+// can be values or matchers such as mock.Anything.
+func (_e *MockRepository_Expecter) Watch(_a0 interface{}) *MockRepository_Watch_Call {
+	return &MockRepository_Watch_Call{Call: _e.mock.On("Watch", _a0)}
+}
+
+// MockRepository_Close_Call is an expected call of MockRepository.Close.
+type MockRepository_Close_Call struct {
+	*mock.Call
+}
+
+// Return sets the values returned by the call.
+func (_c *MockRepository_Close_Call) Return(_r0 error) *MockRepository_Close_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+// Once makes the call expected only once.
+func (_c *MockRepository_Close_Call) Once() *MockRepository_Close_Call {
+	_c.Call.Once()
+	return _c
+}
+
+// Times makes the call expected only i times.
+func (_c *MockRepository_Close_Call) Times(i int) *MockRepository_Close_Call {
+	_c.Call.Times(i)
+	return _c
+}
+
+// Maybe makes the call optional.
+func (_c *MockRepository_Close_Call) Maybe() *MockRepository_Close_Call {
+	_c.Call.Maybe()
+	return _c
+}
+
+// Run sets a function to call with the arguments of the call.
+func (_c *MockRepository_Close_Call) Run(run func()) *MockRepository_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+// MockRepository_Find_Call is an expected call of MockRepository.Find.
+type MockRepository_Find_Call struct {
+	*mock.Call
+}
+
+// Return sets the values returned by the call.
+func (_c *MockRepository_Find_Call) Return(_r0 []*User, _r1 error) *MockRepository_Find_Call {
+	_c.Call.Return(_r0, _r1)
+	return _c
+}
+
+// Once makes the call expected only once.
+func (_c *MockRepository_Find_Call) Once() *MockRepository_Find_Call {
+	_c.Call.Once()
+	return _c
+}
+
+// Times makes the call expected only i times.
+func (_c *MockRepository_Find_Call) Times(i int) *MockRepository_Find_Call {
+	_c.Call.Times(i)
+	return _c
+}
+
+// Maybe makes the call optional.
+func (_c *MockRepository_Find_Call) Maybe() *MockRepository_Find_Call {
+	_c.Call.Maybe()
+	return _c
+}
+
+// Run sets a function to call with the arguments of the call.
+func (_c *MockRepository_Find_Call) Run(run func(ctx context.Context, name string, opts ...Option)) *MockRepository_Find_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		_a0, _ := args.Get(0).(context.Context)
+		_a1, _ := args.Get(1).(string)
+		_va := make([]Option, 0, len(args)-2)
+		for _, _v := range args[2:] {
+			_e, _ := _v.(Option)
+			_va = append(_va, _e)
+		}
+		run(_a0, _a1, _va...)
+	})
+	return _c
+}
+
+// MatchArgument replaces the expected argument at index with matcher, such
+// as mock.Anything.
+func (_c *MockRepository_Find_Call) MatchArgument(index int, matcher interface{}) *MockRepository_Find_Call {
+	_c.Call.MatchArgument(index, matcher)
+	return _c
+}
+
+// MockRepository_Get_Call is an expected call of MockRepository.Get.
+type MockRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Return sets the values returned by the call.
+func (_c *MockRepository_Get_Call) Return(_r0 *User, _r1 error) *MockRepository_Get_Call {
+	_c.Call.Return(_r0, _r1)
+	return _c
+}
+
+// Once makes the call expected only once.
+func (_c *MockRepository_Get_Call) Once() *MockRepository_Get_Call {
+	_c.Call.Once()
+	return _c
+}
+
+// Times makes the call expected only i times.
+func (_c *MockRepository_Get_Call) Times(i int) *MockRepository_Get_Call {
+	_c.Call.Times(i)
+	return _c
+}
+
+// Maybe makes the call optional.
+func (_c *MockRepository_Get_Call) Maybe() *MockRepository_Get_Call {
+	_c.Call.Maybe()
+	return _c
+}
+
+// Run sets a function to call with the arguments of the call.
+func (_c *MockRepository_Get_Call) Run(run func(ctx context.Context, id int)) *MockRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		_a0, _ := args.Get(0).(context.Context)
+		_a1, _ := args.Get(1).(int)
+		run(_a0, _a1)
+	})
+	return _c
+}
+
+// MatchArgument replaces the expected argument at index with matcher, such
+// as mock.Anything.
+func (_c *MockRepository_Get_Call) MatchArgument(index int, matcher interface{}) *MockRepository_Get_Call {
+	_c.Call.MatchArgument(index, matcher)
+	return _c
+}
+
+// MockRepository_Put_Call is an expected call of MockRepository.Put.
+type MockRepository_Put_Call struct {
+	*mock.Call
+}
+
+// Return sets the values returned by the call.
+func (_c *MockRepository_Put_Call) Return(_r0 error) *MockRepository_Put_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+// Once makes the call expected only once.
+func (_c *MockRepository_Put_Call) Once() *MockRepository_Put_Call {
+	_c.Call.Once()
+	return _c
+}
+
+// Times makes the call expected only i times.
+func (_c *MockRepository_Put_Call) Times(i int) *MockRepository_Put_Call {
+	_c.Call.Times(i)
+	return _c
+}
+
+// Maybe makes the call optional.
+func (_c *MockRepository_Put_Call) Maybe() *MockRepository_Put_Call {
+	_c.Call.Maybe()
+	return _c
+}
+
+// Run sets a function to call with the arguments of the call.
+func (_c *MockRepository_Put_Call) Run(run func(_a0 context.Context, _a1 *User)) *MockRepository_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		_a0, _ := args.Get(0).(context.Context)
+		_a1, _ := args.Get(1).(*User)
+		run(_a0, _a1)
+	})
+	return _c
+}
+
+// MatchArgument replaces the expected argument at index with matcher, such
+// as mock.Anything.
+func (_c *MockRepository_Put_Call) MatchArgument(index int, matcher interface{}) *MockRepository_Put_Call {
+	_c.Call.MatchArgument(index, matcher)
+	return _c
+}
+
+// MockRepository_Watch_Call is an expected call of MockRepository.Watch.
+type MockRepository_Watch_Call struct {
+	*mock.Call
+}
+
+// Return sets the values returned by the call.
+func (_c *MockRepository_Watch_Call) Return(_r0 <-chan User, _r1 map[int]*User) *MockRepository_Watch_Call {
+	_c.Call.Return(_r0, _r1)
+	return _c
+}
+
+// Once makes the call expected only once.
+func (_c *MockRepository_Watch_Call) Once() *MockRepository_Watch_Call {
+	_c.Call.Once()
+	return _c
+}
+
+// Times makes the call expected only i times.
+func (_c *MockRepository_Watch_Call) Times(i int) *MockRepository_Watch_Call {
+	_c.Call.Times(i)
+	return _c
+}
+
+// Maybe makes the call optional.
+func (_c *MockRepository_Watch_Call) Maybe() *MockRepository_Watch_Call {
+	_c.Call.Maybe()
+	return _c
+}
+
+// Run sets a function to call with the arguments of the call.
+func (_c *MockRepository_Watch_Call) Run(run func(_a0 func(User))) *MockRepository_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		_a0, _ := args.Get(0).(func(User))
+		run(_a0)
+	})
+	return _c
+}
+
+// MatchArgument replaces the expected argument at index with matcher, such
+// as mock.Anything.
+func (_c *MockRepository_Watch_Call) MatchArgument(index int, matcher interface{}) *MockRepository_Watch_Call {
+	_c.Call.MatchArgument(index, matcher)
+	return _c
+}
+
+// MockClock is a mock implementation of Clock, built on mock.Mock.
+type MockClock struct {
+	mock.Mock
+}
+
+// NewMockClock returns a new MockClock, whose expectations are asserted when the test ends.
+func NewMockClock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockClock {
+	m := &MockClock{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.Mock.AssertExpectations(t) })
+	return m
+}
+
+// Now provides a mock implementation of Clock.Now.
+func (_m *MockClock) Now() time.Time {
+	_args := _m.Mock.MethodCalled("Now")
+	_r0, _ok := _args.Get(0).(time.Time)
+	if !_ok {
+		panic(fmt.Sprintf("mock: MockClock.Now: result 0 should be a time.Time, got %#v", _args.Get(0)))
+	}
+	return _r0
+}
+
+// Tick provides a mock implementation of Clock.Tick.
+func (_m *MockClock) Tick() {
+	_m.Mock.MethodCalled("Tick")
+}
+
+// OnNow expects a call to Now with the arguments given.
+func (_m *MockClock) OnNow() *MockClock_Now_Call {
+	return &MockClock_Now_Call{Call: _m.Mock.On("Now")}
+}
+
+// OnTick expects a call to Tick with the arguments given.
+func (_m *MockClock) OnTick() *MockClock_Tick_Call {
+	return &MockClock_Tick_Call{Call: _m.Mock.On("Tick")}
+}
+
+// MockClock_Expecter sets the expectations of a MockClock with typed helpers.
+type MockClock_Expecter struct {
+	mock *mock.Mock
+}
+
+// EXPECT returns the typed expectation helpers of the mock.
+func (_m *MockClock) EXPECT() *MockClock_Expecter {
+	return &MockClock_Expecter{mock: &_m.Mock}
+}
+
+// Now expects a call to Now.
+func (_e *MockClock_Expecter) Now() *MockClock_Now_Call {
+	return &MockClock_Now_Call{Call: _e.mock.On("Now")}
+}
+
+// Tick expects a call to Tick.
+func (_e *MockClock_Expecter) Tick() *MockClock_Tick_Call {
+	return &MockClock_Tick_Call{Call: _e.mock.On("Tick")}
+}
+
+// MockClock_Now_Call is an expected call of MockClock.Now.
+type MockClock_Now_Call struct {
+	*mock.Call
+}
+
+// Return sets the values returned by the call.
+func (_c *MockClock_Now_Call) Return(_r0 time.Time) *MockClock_Now_Call {
+	_c.Call.Return(_r0)
+	return _c
+}
+
+// Once makes the call expected only once.
+func (_c *MockClock_Now_Call) Once() *MockClock_Now_Call {
+	_c.Call.Once()
+	return _c
+}
+
+// Times makes the call expected only i times.
+func (_c *MockClock_Now_Call) Times(i int) *MockClock_Now_Call {
+	_c.Call.Times(i)
+	return _c
+}
+
+// Maybe makes the call optional.
+func (_c *MockClock_Now_Call) Maybe() *MockClock_Now_Call {
+	_c.Call.Maybe()
+	return _c
+}
+
+// Run sets a function to call with the arguments of the call.
+func (_c *MockClock_Now_Call) Run(run func()) *MockClock_Now_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+// MockClock_Tick_Call is an expected call of MockClock.Tick.
+type MockClock_Tick_Call struct {
+	*mock.Call
+}
+
+// Return sets the values returned by the call.
+func (_c *MockClock_Tick_Call) Return() *MockClock_Tick_Call {
+	_c.Call.Return()
+	return _c
+}
+
+// Once makes the call expected only once.
+func (_c *MockClock_Tick_Call) Once() *MockClock_Tick_Call {
+	_c.Call.Once()
+	return _c
+}
+
+// Times makes the call expected only i times.
+func (_c *MockClock_Tick_Call) Times(i int) *MockClock_Tick_Call {
+	_c.Call.Times(i)
+	return _c
+}
+
+// Maybe makes the call optional.
+func (_c *MockClock_Tick_Call) Maybe() *MockClock_Tick_Call {
+	_c.Call.Maybe()
+	return _c
+}
+
+// Run sets a function to call with the arguments of the call.
+func (_c *MockClock_Tick_Call) Run(run func()) *MockClock_Tick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
@@ -0,0 +1,104 @@
+package example
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMockRepository(t *testing.T) {
+	repo := NewMockRepository(t)
+	ana := &User{ID: 1, Name: "Ana"}
+	repo.EXPECT().Get(mock.Anything, 1).Return(ana, nil).Once()
+	repo.On("Get", mock.Anything, 2).Return(nil, errors.New("not found"))
+	var options int
+	repo.EXPECT().Find(mock.Anything, "Ana", mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, name string, opts ...Option) { options = len(opts) }).
+		Return([]*User{ana}, nil)
+	repo.EXPECT().Close().Return(nil)
+
+	var r Repository = repo
+	user, err := r.Get(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, ana, user)
+	user, err = r.Get(context.Background(), 2)
+	assert.Nil(t, user)
+	assert.EqualError(t, err, "not found")
+
+	noop := func(*time.Duration) {}
+	users, err := r.Find(context.Background(), "Ana", noop, noop)
+	assert.NoError(t, err)
+	assert.Equal(t, []*User{ana}, users)
+	assert.Equal(t, 2, options)
+	assert.NoError(t, r.Close())
+}
+
+func TestMockClock(t *testing.T) {
+	clock := NewMockClock(t)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock.EXPECT().Now().Return(now)
+	clock.EXPECT().Tick().Return()
+
+	var c Clock = clock
+	assert.Equal(t, now, c.Now())
+	c.Tick()
+}
+
+func TestMockRepositoryTyped(t *testing.T) {
+	repo := NewMockRepository(t)
+	ana := &User{ID: 1, Name: "Ana"}
+	ctx := context.Background()
+	repo.OnGet(ctx, 1).Return(ana, nil).Once()
+	repo.OnGet(nil, 2).MatchArgument(0, mock.Anything).Return(nil, errors.New("not found"))
+	repo.OnFind(ctx, "Ana", func(*time.Duration) {}).Return([]*User{ana}, nil)
+	var watched User
+	repo.OnWatch(nil).Run(func(fn func(User)) { fn(*ana) }).Return(nil, nil)
+
+	var r Repository = repo
+	user, err := r.Get(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, ana, user)
+	_, err = r.Get(context.TODO(), 2)
+	assert.EqualError(t, err, "not found")
+	users, err := r.Find(ctx, "Ana", func(d *time.Duration) { *d = time.Second })
+	assert.NoError(t, err)
+	assert.Equal(t, []*User{ana}, users)
+	_, _ = r.Watch(func(u User) { watched = u })
+	assert.Equal(t, *ana, watched)
+}
+
+func TestMockResultTypes(t *testing.T) {
+	repo := NewMockRepository(t)
+	repo.On("Get", mock.Anything, 1).Return(User{ID: 1}, nil)
+	clock := NewMockClock(t)
+	clock.On("Now").Return(nil)
+
+	assert.PanicsWithValue(t, "mock: MockRepository.Get: result 0 should be a *User, got example.User{ID:1, Name:\"\"}", func() {
+		_, _ = repo.Get(context.Background(), 1)
+	})
+	assert.PanicsWithValue(t, "mock: MockClock.Now: result 0 should be a time.Time, got <nil>", func() {
+		clock.Now()
+	})
+}
+
+func TestMockCallChaining(t *testing.T) {
+	repo := NewMockRepository(t)
+	ana := &User{ID: 1, Name: "Ana"}
+	ctx := context.Background()
+	// The typed calls keep their type through Once, Times and Maybe
+	repo.OnGet(ctx, 1).Once().Return(ana, nil)
+	repo.EXPECT().Get(ctx, 2).Times(2).Return(nil, errors.New("not found"))
+	repo.OnClose().Maybe().Return(nil)
+
+	user, err := repo.Get(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, ana, user)
+	for i := 0; i < 2; i++ {
+		_, err = repo.Get(ctx, 2)
+		assert.EqualError(t, err, "not found")
+	}
+}
@@ -0,0 +1,583 @@
+// Command mockgen generates mock implementations of interfaces, built on
+// [github.com/stretchr/testify/mock].
+//
+// Usage:
+//
+//	mockgen [flags] -type Interface[,Interface...] [package]
+//
+// The package, an import path or a directory, is the current directory by
+// default. For each interface Name, mockgen generates:
+//
+//   - a MockName type embedding mock.Mock, whose methods call
+//     mock.Mock.MethodCalled and return the values set with Return, with
+//     their types;
+//   - a NewMockName function, that returns a mock whose expectations are
+//     asserted when the test ends;
+//   - with -expecter, an EXPECT method returning typed expectation helpers,
+//     so that m.EXPECT().Get(ctx, id).Return(user, nil) is checked by the
+//     compiler;
+//   - with -typed, typed expectation methods taking the parameters of the
+//     methods, so that m.OnGet(ctx, id).Return(user, nil) is checked by the
+//     compiler, arguments included. An argument can still be matched with a
+//     matcher such as mock.Anything with MatchArgument:
+//     m.OnGet(nil, id).MatchArgument(0, mock.Anything). Functions cannot be
+//     compared, so arguments of a function type match any function of that
+//     type.
+//
+// The typed helpers record their expectations with mock.Mock.On, so that
+// they are asserted by AssertExpectations like the other ones. Their Once,
+// Times and Maybe methods keep the typed call, so that Return can follow
+// them.
+//
+// The arguments of variadic methods are passed to MethodCalled one by one,
+// so that expectations list them like the other arguments.
+//
+// It is typically run with go generate:
+//
+//	//go:generate go run github.com/stretchr/testify/mockgen -type Repository -out repository_mock_test.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma separated names of the interfaces to mock (required)")
+	out       = flag.String("out", "", "file to write the mocks to, standard output if empty or \"-\"")
+	outPkg    = flag.String("out-pkg", "", "import path of the package of the generated file, the package of the interfaces by default")
+	prefix    = flag.String("prefix", "Mock", "prefix of the names of the mock types")
+	expecter  = flag.Bool("expecter", false, "generate typed expectation helpers, returned by an EXPECT method")
+	typed     = flag.Bool("typed", false, "generate typed expectation methods, named after the methods with an On prefix")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("mockgen: ")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: mockgen [flags] -type Interface[,Interface...] [package]\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	pkgPath := "."
+	if flag.NArg() == 1 {
+		pkgPath = flag.Arg(0)
+	}
+
+	pkg, err := loadPackage(pkgPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	code, err := generate(pkg, strings.Split(*typeNames, ","), *outPkg, *prefix, *expecter, *typed)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := writeOutput(code); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeOutput(code []byte) error {
+	if *out == "" || *out == "-" {
+		_, err := os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(*out, code, 0o644)
+}
+
+// loadPackage parses and type checks the package at pkgPath, an import path
+// or a directory.
+func loadPackage(pkgPath string) (*types.Package, error) {
+	pd, err := build.Import(pkgPath, ".", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, len(pd.GoFiles))
+	for i, name := range pd.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(pd.Dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files[i] = f
+	}
+
+	cfg := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+	}
+	return cfg.Check(pd.ImportPath, fset, files, nil)
+}
+
+// generate returns the source code of the mocks of the interfaces named
+// names in pkg, for the package with the import path outPath.
+func generate(pkg *types.Package, names []string, outPath, prefix string, withExpecter, withTyped bool) ([]byte, error) {
+	if outPath == "" {
+		outPath = pkg.Path()
+	}
+	g := &generator{
+		outPath: outPath,
+		imports: map[string]string{},
+		used:    map[string]string{},
+	}
+	outName := pkg.Name()
+	if outPath != pkg.Path() {
+		outName = packageName(outPath)
+	}
+
+	var mocks []*mockType
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return nil, fmt.Errorf("no type %s in package %s", name, pkg.Path())
+		}
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok {
+			return nil, fmt.Errorf("%s is not an interface", name)
+		}
+		m := g.mockType(prefix+name, types.TypeString(obj.Type(), g.qualifier), iface)
+		if withTyped {
+			if err := m.checkTyped(); err != nil {
+				return nil, err
+			}
+		}
+		mocks = append(mocks, m)
+	}
+	g.qualifier(types.NewPackage("github.com/stretchr/testify/mock", "mock"))
+	if checksResults(mocks) {
+		// Imported before the parameters are named, so that none shadows it
+		g.qualifier(fmtPackage)
+	}
+
+	var body bytes.Buffer
+	for _, m := range mocks {
+		g.writeMock(&body, m, withExpecter, withTyped)
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated with github.com/stretchr/testify/mockgen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", outName)
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	// Standard packages first, like goimports does
+	sort.Slice(paths, func(i, j int) bool {
+		si, sj := isStandard(paths[i]), isStandard(paths[j])
+		if si != sj {
+			return si
+		}
+		return paths[i] < paths[j]
+	})
+	for i, p := range paths {
+		if i > 0 && isStandard(paths[i-1]) && !isStandard(p) {
+			src.WriteString("\n")
+		}
+		if g.imports[p] == packageName(p) {
+			fmt.Fprintf(&src, "\t%q\n", p)
+		} else {
+			fmt.Fprintf(&src, "\t%s %q\n", g.imports[p], p)
+		}
+	}
+	src.WriteString(")\n")
+	_, _ = io.Copy(&src, &body)
+
+	code, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, src.Bytes())
+	}
+	return code, nil
+}
+
+// checksResults reports whether the mocks check the type of results other
+// than errors, which needs fmt.
+func checksResults(mocks []*mockType) bool {
+	for _, m := range mocks {
+		for _, meth := range m.Methods {
+			for _, isError := range meth.Errors {
+				if !isError {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isStandard reports whether the import path p is of a standard package.
+func isStandard(p string) bool {
+	return !strings.Contains(strings.SplitN(p, "/", 2)[0], ".")
+}
+
+// packageName returns the likely name of the package with the import path p.
+func packageName(p string) string {
+	name := path.Base(p)
+	if strings.HasPrefix(name, "v") {
+		if _, err := strconv.Atoi(name[1:]); err == nil && path.Dir(p) != "." {
+			name = path.Base(path.Dir(p))
+		}
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		if _, err := strconv.Atoi(name[i+2:]); err == nil {
+			name = name[:i]
+		}
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// generator holds the state of the generation of a file.
+type generator struct {
+	outPath string
+	// imports maps the imported paths to their names, and used maps the
+	// names back to the paths.
+	imports map[string]string
+	used    map[string]string
+}
+
+// qualifier is a types.Qualifier that adds the packages it qualifies to the
+// imports of the file.
+func (g *generator) qualifier(p *types.Package) string {
+	if p.Path() == g.outPath {
+		return ""
+	}
+	if name, ok := g.imports[p.Path()]; ok {
+		return name
+	}
+	name := p.Name()
+	for i := 2; g.used[name] != ""; i++ {
+		name = fmt.Sprintf("%s%d", p.Name(), i)
+	}
+	g.imports[p.Path()] = name
+	g.used[name] = p.Path()
+	return name
+}
+
+type mockType struct {
+	Name      string
+	Interface string
+	Methods   []*method
+}
+
+type method struct {
+	Name    string
+	Params  []param
+	Results []string
+	Errors  []bool
+	// Nillable reports whether each result can be nil.
+	Nillable []bool
+	Variadic bool
+}
+
+type param struct {
+	Name string
+	Type string
+	// Func reports whether the parameter, or its elements if variadic, is
+	// a function.
+	Func bool
+}
+
+var (
+	errorType  = types.Universe.Lookup("error").Type()
+	fmtPackage = types.NewPackage("fmt", "fmt")
+)
+
+func (g *generator) mockType(name, iface string, t *types.Interface) *mockType {
+	m := &mockType{Name: name, Interface: iface}
+	for i := 0; i < t.NumMethods(); i++ {
+		fn := t.Method(i)
+		sig := fn.Type().(*types.Signature)
+		meth := &method{Name: fn.Name(), Variadic: sig.Variadic()}
+		for j := 0; j < sig.Params().Len(); j++ {
+			p := sig.Params().At(j)
+			typ := p.Type()
+			if meth.Variadic && j == sig.Params().Len()-1 {
+				typ = typ.(*types.Slice).Elem()
+			}
+			_, isFunc := typ.Underlying().(*types.Signature)
+			meth.Params = append(meth.Params, param{Name: p.Name(), Type: types.TypeString(typ, g.qualifier), Func: isFunc})
+		}
+		for j := 0; j < sig.Results().Len(); j++ {
+			r := sig.Results().At(j).Type()
+			meth.Results = append(meth.Results, types.TypeString(r, g.qualifier))
+			meth.Errors = append(meth.Errors, types.Identical(r, errorType))
+			meth.Nillable = append(meth.Nillable, isNillable(r))
+		}
+		m.Methods = append(m.Methods, meth)
+	}
+	return m
+}
+
+// isNillable reports whether nil is a value of the type t.
+func isNillable(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Interface, *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature:
+		return true
+	case *types.Basic:
+		return u.Kind() == types.UnsafePointer
+	}
+	return false
+}
+
+// checkTyped returns an error if the typed expectation methods of m would
+// clash with its other methods.
+func (m *mockType) checkTyped() error {
+	for _, meth := range m.Methods {
+		for _, other := range m.Methods {
+			if other.Name == "On"+meth.Name {
+				return fmt.Errorf("%s.%s clashes with the typed expectation method of %s, generate it without -typed", m.Interface, other.Name, meth.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// paramNames returns the names of the parameters of m, made unique and
+// distinct from the imported packages.
+func (g *generator) paramNames(m *method) []string {
+	names := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		name := p.Name
+		if name == "" || name == "_" || g.used[name] != "" || strings.HasPrefix(name, "_") {
+			name = fmt.Sprintf("_a%d", i)
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// signature returns the parameters of m with the names given, and its
+// results.
+func (m *method) signature(names []string) (string, string) {
+	params := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		if m.Variadic && i == len(m.Params)-1 {
+			params[i] = names[i] + " ..." + p.Type
+		} else {
+			params[i] = names[i] + " " + p.Type
+		}
+	}
+	results := strings.Join(m.Results, ", ")
+	if len(m.Results) > 1 {
+		results = "(" + results + ")"
+	}
+	return strings.Join(params, ", "), results
+}
+
+// callArgs writes the statements building the arguments of a call to m in
+// the variable _ca, if m is variadic, and returns the arguments to pass to
+// a function taking ...interface{}.
+func (m *method) callArgs(w io.Writer, names []string) string {
+	if !m.Variadic {
+		return strings.Join(names, ", ")
+	}
+	last := len(names) - 1
+	fmt.Fprintf(w, "\t_ca := []interface{}{%s}\n", strings.Join(names[:last], ", "))
+	fmt.Fprintf(w, "\tfor _, _v := range %s {\n\t\t_ca = append(_ca, _v)\n\t}\n", names[last])
+	return "_ca..."
+}
+
+func (g *generator) writeMock(w io.Writer, m *mockType, withExpecter, withTyped bool) {
+	fmt.Fprintf(w, "\n// %s is a mock implementation of %s, built on mock.Mock.\n", m.Name, m.Interface)
+	fmt.Fprintf(w, "type %s struct {\n\tmock.Mock\n}\n", m.Name)
+
+	fmt.Fprintf(w, "\n// New%[1]s returns a new %[1]s, whose expectations are asserted when the test ends.\n", m.Name)
+	fmt.Fprintf(w, "func New%[1]s(t interface {\n\tmock.TestingT\n\tCleanup(func())\n}) *%[1]s {\n", m.Name)
+	fmt.Fprintf(w, "\tm := &%s{}\n\tm.Mock.Test(t)\n\tt.Cleanup(func() { m.Mock.AssertExpectations(t) })\n\treturn m\n}\n", m.Name)
+
+	for _, meth := range m.Methods {
+		names := g.paramNames(meth)
+		params, results := meth.signature(names)
+		fmt.Fprintf(w, "\n// %s provides a mock implementation of %s.%s.\n", meth.Name, m.Interface, meth.Name)
+		fmt.Fprintf(w, "func (_m *%s) %s(%s) %s {\n", m.Name, meth.Name, params, results)
+		args := meth.callArgs(w, names)
+		if args != "" {
+			args = ", " + args
+		}
+		if len(meth.Results) == 0 {
+			fmt.Fprintf(w, "\t_m.Mock.MethodCalled(%q%s)\n}\n", meth.Name, args)
+			continue
+		}
+		fmt.Fprintf(w, "\t_args := _m.Mock.MethodCalled(%q%s)\n", meth.Name, args)
+		returned := make([]string, len(meth.Results))
+		for i, r := range meth.Results {
+			if meth.Errors[i] {
+				returned[i] = fmt.Sprintf("_args.Error(%d)", i)
+				continue
+			}
+			// A result of the wrong type panics like the getters of
+			// mock.Arguments do, rather than being returned as a zero value
+			fmt.Fprintf(w, "\t_r%d, _ok := _args.Get(%d).(%s)\n", i, i, r)
+			if meth.Nillable[i] {
+				fmt.Fprintf(w, "\tif !_ok && _args.Get(%d) != nil {\n", i)
+			} else {
+				fmt.Fprintf(w, "\tif !_ok {\n")
+			}
+			msg := fmt.Sprintf("mock: %s.%s: result %d should be a %s, got %%#v", m.Name, meth.Name, i, r)
+			fmt.Fprintf(w, "\t\tpanic(%s.Sprintf(%q, _args.Get(%d)))\n\t}\n", g.qualifier(fmtPackage), msg, i)
+			returned[i] = fmt.Sprintf("_r%d", i)
+		}
+		fmt.Fprintf(w, "\treturn %s\n}\n", strings.Join(returned, ", "))
+	}
+
+	if withTyped {
+		g.writeTyped(w, m)
+	}
+	if withExpecter {
+		g.writeExpecter(w, m)
+	}
+	if withTyped || withExpecter {
+		g.writeCalls(w, m)
+	}
+}
+
+// callArgsOn is like callArgs, for arguments passed to mock.Mock.On: as
+// functions cannot be compared, the arguments of a function type are
+// matched with mock.IsType.
+func (m *method) callArgsOn(w io.Writer, names []string) string {
+	args := make([]string, len(names))
+	for i, name := range names {
+		args[i] = name
+		if m.Params[i].Func {
+			args[i] = "mock.IsType(" + name + ")"
+		}
+	}
+	if !m.Variadic {
+		return strings.Join(args, ", ")
+	}
+	last := len(names) - 1
+	fmt.Fprintf(w, "\t_ca := []interface{}{%s}\n", strings.Join(args[:last], ", "))
+	elem := "_v"
+	if m.Params[last].Func {
+		elem = "mock.IsType(_v)"
+	}
+	fmt.Fprintf(w, "\tfor _, _v := range %s {\n\t\t_ca = append(_ca, %s)\n\t}\n", names[last], elem)
+	return "_ca..."
+}
+
+func (g *generator) writeTyped(w io.Writer, m *mockType) {
+	for _, meth := range m.Methods {
+		callName := m.Name + "_" + meth.Name + "_Call"
+		names := g.paramNames(meth)
+		params, _ := meth.signature(names)
+
+		fmt.Fprintf(w, "\n// On%s expects a call to %s with the arguments given.", meth.Name, meth.Name)
+		if len(names) > 0 {
+			fmt.Fprintf(w, " Use MatchArgument\n// to match an argument with a matcher such as mock.Anything.")
+		}
+		fmt.Fprintf(w, "\nfunc (_m *%s) On%s(%s) *%s {\n", m.Name, meth.Name, params, callName)
+		args := meth.callArgsOn(w, names)
+		if args != "" {
+			args = ", " + args
+		}
+		fmt.Fprintf(w, "\treturn &%s{Call: _m.Mock.On(%q%s)}\n}\n", callName, meth.Name, args)
+	}
+}
+
+func (g *generator) writeExpecter(w io.Writer, m *mockType) {
+	expecterName := m.Name + "_Expecter"
+	fmt.Fprintf(w, "\n// %s sets the expectations of a %s with typed helpers.\n", expecterName, m.Name)
+	fmt.Fprintf(w, "type %s struct {\n\tmock *mock.Mock\n}\n", expecterName)
+	fmt.Fprintf(w, "\n// EXPECT returns the typed expectation helpers of the mock.\n")
+	fmt.Fprintf(w, "func (_m *%s) EXPECT() *%s {\n\treturn &%s{mock: &_m.Mock}\n}\n", m.Name, expecterName, expecterName)
+
+	for _, meth := range m.Methods {
+		callName := m.Name + "_" + meth.Name + "_Call"
+		names := g.paramNames(meth)
+
+		params := make([]string, len(names))
+		for i, name := range names {
+			params[i] = name + " interface{}"
+		}
+		if meth.Variadic {
+			params[len(params)-1] = names[len(names)-1] + " ...interface{}"
+		}
+		if len(names) == 0 {
+			fmt.Fprintf(w, "\n// %s expects a call to %s.\n", meth.Name, meth.Name)
+		} else {
+			fmt.Fprintf(w, "\n// %s expects a call to %s with arguments matching the ones given, which\n// can be values or matchers such as mock.Anything.\n", meth.Name, meth.Name)
+		}
+		fmt.Fprintf(w, "func (_e *%s) %s(%s) *%s {\n", expecterName, meth.Name, strings.Join(params, ", "), callName)
+		args := meth.callArgs(w, names)
+		if args != "" {
+			args = ", " + args
+		}
+		fmt.Fprintf(w, "\treturn &%s{Call: _e.mock.On(%q%s)}\n}\n", callName, meth.Name, args)
+	}
+}
+
+// writeCalls writes the typed expected calls returned by the expectation
+// helpers.
+func (g *generator) writeCalls(w io.Writer, m *mockType) {
+	for _, meth := range m.Methods {
+		callName := m.Name + "_" + meth.Name + "_Call"
+		names := g.paramNames(meth)
+
+		fmt.Fprintf(w, "\n// %s is an expected call of %s.%s.\n", callName, m.Name, meth.Name)
+		fmt.Fprintf(w, "type %s struct {\n\t*mock.Call\n}\n", callName)
+		results := make([]string, len(meth.Results))
+		returned := make([]string, len(meth.Results))
+		for i, r := range meth.Results {
+			results[i] = fmt.Sprintf("_r%d %s", i, r)
+			returned[i] = fmt.Sprintf("_r%d", i)
+		}
+		fmt.Fprintf(w, "\n// Return sets the values returned by the call.\n")
+		fmt.Fprintf(w, "func (_c *%s) Return(%s) *%s {\n", callName, strings.Join(results, ", "), callName)
+		fmt.Fprintf(w, "\t_c.Call.Return(%s)\n\treturn _c\n}\n", strings.Join(returned, ", "))
+
+		fmt.Fprintf(w, "\n// Once makes the call expected only once.\n")
+		fmt.Fprintf(w, "func (_c *%s) Once() *%s {\n\t_c.Call.Once()\n\treturn _c\n}\n", callName, callName)
+		fmt.Fprintf(w, "\n// Times makes the call expected only i times.\n")
+		fmt.Fprintf(w, "func (_c *%s) Times(i int) *%s {\n\t_c.Call.Times(i)\n\treturn _c\n}\n", callName, callName)
+		fmt.Fprintf(w, "\n// Maybe makes the call optional.\n")
+		fmt.Fprintf(w, "func (_c *%s) Maybe() *%s {\n\t_c.Call.Maybe()\n\treturn _c\n}\n", callName, callName)
+
+		runParams, _ := meth.signature(names)
+		fmt.Fprintf(w, "\n// Run sets a function to call with the arguments of the call.\n")
+		fmt.Fprintf(w, "func (_c *%s) Run(run func(%s)) *%s {\n", callName, runParams, callName)
+		fmt.Fprintf(w, "\t_c.Call.Run(func(args mock.Arguments) {\n")
+		passed := make([]string, len(meth.Params))
+		for i, p := range meth.Params {
+			if meth.Variadic && i == len(meth.Params)-1 {
+				fmt.Fprintf(w, "\t\t_va := make([]%s, 0, len(args)-%d)\n", p.Type, i)
+				fmt.Fprintf(w, "\t\tfor _, _v := range args[%d:] {\n\t\t\t_e, _ := _v.(%s)\n\t\t\t_va = append(_va, _e)\n\t\t}\n", i, p.Type)
+				passed[i] = "_va..."
+				continue
+			}
+			fmt.Fprintf(w, "\t\t_a%d, _ := args.Get(%d).(%s)\n", i, i, p.Type)
+			passed[i] = fmt.Sprintf("_a%d", i)
+		}
+		fmt.Fprintf(w, "\t\trun(%s)\n\t})\n\treturn _c\n}\n", strings.Join(passed, ", "))
+
+		if len(names) > 0 {
+			fmt.Fprintf(w, "\n// MatchArgument replaces the expected argument at index with matcher, such\n// as mock.Anything.\n")
+			fmt.Fprintf(w, "func (_c *%s) MatchArgument(index int, matcher interface{}) *%s {\n", callName, callName)
+			fmt.Fprintf(w, "\t_c.Call.MatchArgument(index, matcher)\n\treturn _c\n}\n")
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"go/types"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const examplePkg = "github.com/stretchr/testify/mockgen/internal/example"
+
+var (
+	exampleOnce sync.Once
+	example     *types.Package
+	exampleErr  error
+)
+
+// loadExample loads the example package once, as type checking it from
+// source is slow.
+func loadExample(t *testing.T) *types.Package {
+	exampleOnce.Do(func() {
+		example, exampleErr = loadPackage(examplePkg)
+	})
+	require.NoError(t, exampleErr)
+	return example
+}
+
+func TestGenerate(t *testing.T) {
+	pkg := loadExample(t)
+
+	code, err := generate(pkg, []string{"Repository", "Clock"}, "", "Mock", true, true)
+	require.NoError(t, err)
+	expected, err := os.ReadFile("internal/example/example_mock.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(code), "run go generate ./mockgen/... to update the example")
+}
+
+func TestGenerateOtherPackage(t *testing.T) {
+	pkg := loadExample(t)
+
+	code, err := generate(pkg, []string{"Clock"}, "example.com/mocks", "Fake", false, false)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "package mocks\n")
+	assert.Contains(t, string(code), "\t\""+examplePkg+"\"\n")
+	assert.Contains(t, string(code), "// FakeClock is a mock implementation of example.Clock, built on mock.Mock.\n")
+	assert.NotContains(t, string(code), "EXPECT")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	pkg := loadExample(t)
+
+	_, err := generate(pkg, []string{"Store"}, "", "Mock", false, false)
+	assert.EqualError(t, err, "no type Store in package "+examplePkg)
+	_, err = generate(pkg, []string{"User"}, "", "Mock", false, false)
+	assert.EqualError(t, err, "User is not an interface")
+}
+
+func TestPackageName(t *testing.T) {
+	assert.Equal(t, "yaml", packageName("gopkg.in/yaml.v3"))
+	assert.Equal(t, "chi", packageName("github.com/go-chi/chi/v5"))
+	assert.Equal(t, "mock", packageName("github.com/stretchr/testify/mock"))
+}
+
+func TestCheckTyped(t *testing.T) {
+	m := &mockType{Name: "MockStore", Interface: "Store", Methods: []*method{{Name: "Get"}, {Name: "OnGet"}}}
+	assert.EqualError(t, m.checkTyped(), "Store.OnGet clashes with the typed expectation method of Get, generate it without -typed")
+	m.Methods = m.Methods[:1]
+	assert.NoError(t, m.checkTyped())
+}
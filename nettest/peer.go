@@ -0,0 +1,327 @@
+// Package nettest provides a scripted peer to test code that speaks a
+// protocol over a net.Conn.
+//
+// The script of a Peer lists what the peer expects to receive and what it
+// sends in return. Start runs it against one end of a net.Pipe, and returns
+// the other end for the code under test. AssertExpectations then waits for
+// the script to finish and checks that nothing was sent after it, and on
+// failure reports where it stopped with a diff of the expected and actual
+// transcripts.
+//
+//	peer := nettest.NewPeer().
+//		Send([]byte("220 ready\r\n")).
+//		ExpectRegexp(`HELO \S+\r\n`).
+//		Send([]byte("250 hello\r\n")).
+//		Expect([]byte("QUIT\r\n")).
+//		Close()
+//
+//	client := smtp.NewClient(peer.Start())
+//	// ... code under test using client ...
+//
+//	peer.AssertExpectations(t)
+package nettest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/internal/difflib"
+)
+
+// DefaultTimeout is how long each step of a script waits for the code under
+// test, until set otherwise with Peer.Timeout.
+const DefaultTimeout = time.Second
+
+// drainTimeout is how long AssertExpectations waits for data sent after the
+// last step of a script.
+const drainTimeout = 50 * time.Millisecond
+
+type stepKind int
+
+const (
+	expectBytes stepKind = iota
+	expectRegexp
+	send
+	hangUp
+)
+
+// step is a step of the script of a Peer.
+type step struct {
+	kind    stepKind
+	data    []byte
+	pattern string
+	rx      *regexp.Regexp
+	timeout time.Duration
+}
+
+func (s step) String() string {
+	switch s.kind {
+	case expectBytes:
+		return fmt.Sprintf("recv %q", s.data)
+	case expectRegexp:
+		return fmt.Sprintf("recv /%s/", s.pattern)
+	case send:
+		return fmt.Sprintf("send %q", s.data)
+	default:
+		return "close"
+	}
+}
+
+// Peer is the scripted end of a connection. Its steps are added with Expect,
+// ExpectRegexp, Send and Close, which return the peer so that calls can be
+// chained, and must all be added before Start.
+type Peer struct {
+	steps   []step
+	timeout time.Duration
+
+	conn    net.Conn
+	started bool
+	done    chan struct{}
+
+	mutex      sync.Mutex
+	transcript []string
+	failure    string
+	// leftover is the data received after the last match of a script that
+	// ran to the end.
+	leftover []byte
+}
+
+// NewPeer returns a peer with an empty script.
+func NewPeer() *Peer {
+	return &Peer{
+		timeout: DefaultTimeout,
+		done:    make(chan struct{}),
+	}
+}
+
+func (p *Peer) add(s step) *Peer {
+	if p.started {
+		panic("nettest: steps must be added before Start")
+	}
+	s.timeout = p.timeout
+	p.steps = append(p.steps, s)
+	return p
+}
+
+// Timeout sets how long the steps added after it wait for the code under test
+// to send the expected data, or to read the data sent.
+func (p *Peer) Timeout(timeout time.Duration) *Peer {
+	p.timeout = timeout
+	return p
+}
+
+// Expect adds a step that expects to receive data.
+func (p *Peer) Expect(data []byte) *Peer {
+	return p.add(step{kind: expectBytes, data: data})
+}
+
+// ExpectRegexp adds a step that expects to receive data matching the regexp
+// rx, a string or a *regexp.Regexp. The match is anchored at the start of the
+// received data and is made as soon as possible, so rx should end with a
+// delimiter such as `\r\n`. The data after the match is left for the next
+// step.
+func (p *Peer) ExpectRegexp(rx interface{}) *Peer {
+	var pattern string
+	if r, ok := rx.(*regexp.Regexp); ok {
+		pattern = r.String()
+	} else {
+		pattern = fmt.Sprint(rx)
+	}
+	return p.add(step{kind: expectRegexp, pattern: pattern, rx: regexp.MustCompile(`\A(?:` + pattern + `)`)})
+}
+
+// Send adds a step that sends data.
+func (p *Peer) Send(data []byte) *Peer {
+	return p.add(step{kind: send, data: data})
+}
+
+// Close adds a step that closes the connection.
+func (p *Peer) Close() *Peer {
+	return p.add(step{kind: hangUp})
+}
+
+// Start runs the script in a new goroutine, and returns the end of the
+// connection for the code under test.
+func (p *Peer) Start() net.Conn {
+	if p.started {
+		panic("nettest: peer already started")
+	}
+	p.started = true
+
+	client, conn := net.Pipe()
+	p.conn = conn
+	go p.run()
+	return client
+}
+
+func (p *Peer) run() {
+	defer close(p.done)
+
+	var received []byte
+	for i, s := range p.steps {
+		var actual, failure string
+		switch s.kind {
+		case expectBytes, expectRegexp:
+			actual, failure = p.expect(s, &received)
+		case send:
+			actual, failure = p.send(s)
+		default:
+			actual = s.String()
+			_ = p.conn.Close()
+		}
+
+		p.mutex.Lock()
+		p.transcript = append(p.transcript, actual)
+		if failure != "" {
+			p.failure = fmt.Sprintf("Script failed at step %d of %d: %s", i+1, len(p.steps), failure)
+		}
+		p.mutex.Unlock()
+		if failure != "" {
+			_ = p.conn.Close()
+			return
+		}
+	}
+
+	p.mutex.Lock()
+	p.leftover = received
+	p.mutex.Unlock()
+}
+
+// expect reads until received matches the step s, and consumes the match.
+func (p *Peer) expect(s step, received *[]byte) (actual, failure string) {
+	_ = p.conn.SetReadDeadline(time.Now().Add(s.timeout))
+	buf := make([]byte, 4096)
+	for {
+		switch s.kind {
+		case expectBytes:
+			n := len(s.data)
+			if len(*received) < n {
+				n = len(*received)
+			}
+			if !bytes.Equal((*received)[:n], s.data[:n]) {
+				got := (*received)[:n]
+				return fmt.Sprintf("recv %q", got), fmt.Sprintf("expected %q, got %q", s.data, got)
+			}
+			if n == len(s.data) {
+				*received = (*received)[n:]
+				return s.String(), ""
+			}
+		case expectRegexp:
+			if loc := s.rx.FindIndex(*received); loc != nil {
+				*received = (*received)[loc[1]:]
+				return s.String(), ""
+			}
+		}
+
+		n, err := p.conn.Read(buf)
+		*received = append(*received, buf[:n]...)
+		if err != nil {
+			var reason string
+			var netErr net.Error
+			switch {
+			case errors.As(err, &netErr) && netErr.Timeout(), errors.Is(err, os.ErrDeadlineExceeded):
+				reason = fmt.Sprintf("timed out after %v", s.timeout)
+			case errors.Is(err, io.EOF), errors.Is(err, io.ErrClosedPipe):
+				reason = "connection closed"
+			default:
+				reason = err.Error()
+			}
+			return fmt.Sprintf("recv %q <%s>", *received, reason),
+				fmt.Sprintf("%s waiting for %s, got %q", reason, strings.TrimPrefix(s.String(), "recv "), *received)
+		}
+	}
+}
+
+// send writes the data of the step s.
+func (p *Peer) send(s step) (actual, failure string) {
+	_ = p.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	n, err := p.conn.Write(s.data)
+	if err == nil {
+		return s.String(), ""
+	}
+
+	reason := err.Error()
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		reason = fmt.Sprintf("timed out after %v", s.timeout)
+	} else if errors.Is(err, io.ErrClosedPipe) {
+		reason = "connection closed"
+	}
+	return fmt.Sprintf("send %q <%s>", s.data[:n], reason),
+		fmt.Sprintf("%s sending %q, the client read %d of %d bytes", reason, s.data, n, len(s.data))
+}
+
+// drain fails a script that ran to the end if the code under test sent data
+// after its last step, waiting for it at most drainTimeout unless the script
+// closed the connection.
+func (p *Peer) drain() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.failure != "" {
+		return
+	}
+
+	extra := p.leftover
+	if len(p.steps) == 0 || p.steps[len(p.steps)-1].kind != hangUp {
+		_ = p.conn.SetReadDeadline(time.Now().Add(drainTimeout))
+		buf := make([]byte, 4096)
+		for {
+			n, err := p.conn.Read(buf)
+			extra = append(extra, buf[:n]...)
+			if err != nil {
+				break
+			}
+		}
+	}
+	if len(extra) > 0 {
+		p.transcript = append(p.transcript, fmt.Sprintf("recv %q", extra))
+		p.failure = fmt.Sprintf("Script ended at step %d of %d, but received %q after it", len(p.steps), len(p.steps), extra)
+	}
+}
+
+// AssertExpectations waits for the script to finish, and asserts that all its
+// steps were carried out and that no data was sent after the last one. The
+// connection is then closed.
+func (p *Peer) AssertExpectations(t assert.TestingT) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if !p.started {
+		return assert.Fail(t, "Peer was never started")
+	}
+
+	<-p.done
+	p.drain()
+	_ = p.conn.Close()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.failure == "" {
+		return true
+	}
+
+	expected := make([]string, len(p.steps))
+	for i, s := range p.steps {
+		expected[i] = s.String() + "\n"
+	}
+	actual := make([]string, len(p.transcript))
+	for i, line := range p.transcript {
+		actual[i] = line + "\n"
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        expected,
+		B:        actual,
+		FromFile: "Expected",
+		ToFile:   "Actual",
+		Context:  len(expected),
+	})
+	return assert.Fail(t, p.failure+"\n\nTranscript:\n"+diff)
+}
@@ -0,0 +1,123 @@
+package nettest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureT records the failures reported to it.
+type captureT struct {
+	msg string
+}
+
+func (t *captureT) Errorf(format string, args ...interface{}) {
+	t.msg += fmt.Sprintf(format, args...)
+}
+
+func TestPeer(t *testing.T) {
+	t.Parallel()
+
+	peer := NewPeer().
+		Send([]byte("220 ready\r\n")).
+		ExpectRegexp(`HELO \S+\r\n`).
+		Send([]byte("250 hello\r\n")).
+		Expect([]byte("QUIT\r\n")).
+		Close()
+	conn := peer.Start()
+
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "220 ready\r\n", line)
+	// Split writes are joined, and the data after a match is kept
+	_, _ = io.WriteString(conn, "HELO exa")
+	_, _ = io.WriteString(conn, "mple.com\r\nQUIT\r\n")
+	line, _ = r.ReadString('\n')
+	assert.Equal(t, "250 hello\r\n", line)
+	_, err = r.ReadByte()
+	assert.Equal(t, io.EOF, err)
+
+	assert.True(t, peer.AssertExpectations(t))
+}
+
+func TestPeerMismatch(t *testing.T) {
+	t.Parallel()
+
+	peer := NewPeer().
+		Send([]byte("220 ready\r\n")).
+		Expect([]byte("HELO example.com\r\n")).
+		Send([]byte("250 hello\r\n"))
+	conn := peer.Start()
+	_, _ = bufio.NewReader(conn).ReadString('\n')
+	_, _ = io.WriteString(conn, "EHLO example.com\r\n")
+
+	mockT := new(captureT)
+	assert.False(t, peer.AssertExpectations(mockT))
+	assert.Contains(t, mockT.msg, `Script failed at step 2 of 3: expected "HELO example.com\r\n", got "EHLO example.com\r\n"`)
+	for _, line := range []string{
+		`@@ -1,3 +1,2 @@`,
+		` send "220 ready\r\n"`,
+		`-recv "HELO example.com\r\n"`,
+		`-send "250 hello\r\n"`,
+		`+recv "EHLO example.com\r\n"`,
+	} {
+		assert.Contains(t, mockT.msg, "\t"+line+"\n")
+	}
+}
+
+func TestPeerDataAfterScript(t *testing.T) {
+	t.Parallel()
+
+	peer := NewPeer().Expect([]byte("PING\r\n"))
+	conn := peer.Start()
+	_, _ = io.WriteString(conn, "PING\r\nPONG")
+	mockT := new(captureT)
+	assert.False(t, peer.AssertExpectations(mockT))
+	assert.Contains(t, mockT.msg, `Script ended at step 1 of 1, but received "PONG" after it`)
+	assert.Contains(t, mockT.msg, "\t+recv \"PONG\"\n")
+
+	peer = NewPeer().Expect([]byte("PING\r\n"))
+	conn = peer.Start()
+	_, _ = io.WriteString(conn, "PING\r\n")
+	go func() { _, _ = io.WriteString(conn, "QUIT\r\n") }()
+	mockT = new(captureT)
+	assert.False(t, peer.AssertExpectations(mockT))
+	assert.Contains(t, mockT.msg, `Script ended at step 1 of 1, but received "QUIT\r\n" after it`)
+}
+
+func TestPeerTimeouts(t *testing.T) {
+	t.Parallel()
+
+	peer := NewPeer().Timeout(10 * time.Millisecond).ExpectRegexp(regexp.MustCompile(`PING\r\n`))
+	conn := peer.Start()
+	_, _ = io.WriteString(conn, "PIN")
+	mockT := new(captureT)
+	assert.False(t, peer.AssertExpectations(mockT))
+	assert.Contains(t, mockT.msg, `Script failed at step 1 of 1: timed out after 10ms waiting for /PING\r\n/, got "PIN"`)
+	assert.Contains(t, mockT.msg, `+recv "PIN" <timed out after 10ms>`)
+
+	peer = NewPeer().Timeout(10 * time.Millisecond).Send([]byte("hello"))
+	peer.Start()
+	mockT = new(captureT)
+	assert.False(t, peer.AssertExpectations(mockT))
+	assert.Contains(t, mockT.msg, `Script failed at step 1 of 1: timed out after 10ms sending "hello", the client read 0 of 5 bytes`)
+
+	peer = NewPeer().Expect([]byte("QUIT\r\n"))
+	conn = peer.Start()
+	_, _ = io.WriteString(conn, "QU")
+	_ = conn.Close()
+	mockT = new(captureT)
+	assert.False(t, peer.AssertExpectations(mockT))
+	assert.Contains(t, mockT.msg, `Script failed at step 1 of 1: connection closed waiting for "QUIT\r\n", got "QU"`)
+
+	mockT = new(captureT)
+	assert.False(t, NewPeer().AssertExpectations(mockT))
+	assert.True(t, strings.Contains(mockT.msg, "Peer was never started"))
+}
@@ -3,12 +3,107 @@
 package require
 
 import (
+	context "context"
 	assert "github.com/stretchr/testify/assert"
+	io "io"
+	fs "io/fs"
 	http "net/http"
 	url "net/url"
 	time "time"
 )
 
+// BytesEqual asserts that two byte slices are equal. On failure, it shows a
+// hexdump of both around the first difference, which is easier to read than
+// the diff of Equal for binary data.
+//
+//	require.BytesEqual(t, []byte{0x01, 0x02}, frame)
+func BytesEqual(t TestingT, expected []byte, actual []byte, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.BytesEqual(t, expected, actual, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// BytesEqualf asserts that two byte slices are equal. On failure, it shows a
+// hexdump of both around the first difference, which is easier to read than
+// the diff of Equal for binary data.
+//
+//	require.BytesEqualf(t, []byte{0x01, 0x02}, frame, "error message %s", "formatted")
+func BytesEqualf(t TestingT, expected []byte, actual []byte, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.BytesEqualf(t, expected, actual, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// CapturePanic asserts that the code inside the specified PanicTestFunc
+// panics, and returns the recovered value and the stack of the panic so that
+// they can be checked further.
+//
+//	info, ok := require.CapturePanic(t, func(){ GoCrazy() })
+//	ok
+//	require.Contains(t, info.Stack, "GoCrazy")
+func CapturePanic(t TestingT, f assert.PanicTestFunc, msgAndArgs ...interface{}) assert.PanicInfo {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.CapturePanic(t, f, msgAndArgs...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
+// CapturePanicf asserts that the code inside the specified PanicTestFunc
+// panics, and returns the recovered value and the stack of the panic so that
+// they can be checked further.
+//
+//	info, ok := require.CapturePanicf(t, func(){ GoCrazy() }, "error message %s", "formatted")
+//	ok
+//	require.Contains(t, info.Stack, "GoCrazy")
+func CapturePanicf(t TestingT, f assert.PanicTestFunc, msg string, args ...interface{}) assert.PanicInfo {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.CapturePanicf(t, f, msg, args...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
+// ChanEmpty asserts that there are no values in the buffer of the channel ch.
+//
+//	require.ChanEmpty(t, events)
+func ChanEmpty(t TestingT, ch interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ChanEmpty(t, ch, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ChanEmptyf asserts that there are no values in the buffer of the channel ch.
+//
+//	require.ChanEmptyf(t, events, "error message %s", "formatted")
+func ChanEmptyf(t TestingT, ch interface{}, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ChanEmptyf(t, ch, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Condition uses a Comparison to assert a complex condition.
 func Condition(t TestingT, comp assert.Comparison, msgAndArgs ...interface{}) {
 	if h, ok := t.(tHelper); ok {
@@ -47,6 +142,34 @@ func Contains(t TestingT, s interface{}, contains interface{}, msgAndArgs ...int
 	t.FailNow()
 }
 
+// ContainsAny asserts that the specified string contains at least one of the
+// Unicode code points in chars.
+//
+//	require.ContainsAny(t, "Hello World", "xyz!W")
+func ContainsAny(t TestingT, str string, chars string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ContainsAny(t, str, chars, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ContainsAnyf asserts that the specified string contains at least one of the
+// Unicode code points in chars.
+//
+//	require.ContainsAnyf(t, "Hello World", "xyz!W", "error message %s", "formatted")
+func ContainsAnyf(t TestingT, str string, chars string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ContainsAnyf(t, str, chars, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Containsf asserts that the specified string, list(array, slice...) or map contains the
 // specified substring or element.
 //
@@ -63,6 +186,34 @@ func Containsf(t TestingT, s interface{}, contains interface{}, msg string, args
 	t.FailNow()
 }
 
+// DirContains asserts that the directory dir in fsys contains each of the
+// entries, which are names of files or directories directly inside dir.
+//
+//	require.DirContains(t, os.DirFS("out"), ".", []string{"main.go", "internal"})
+func DirContains(t TestingT, fsys fs.FS, dir string, entries []string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.DirContains(t, fsys, dir, entries, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// DirContainsf asserts that the directory dir in fsys contains each of the
+// entries, which are names of files or directories directly inside dir.
+//
+//	require.DirContainsf(t, os.DirFS("out"), ".", []string{"main.go", "internal"}, "error message %s", "formatted")
+func DirContainsf(t TestingT, fsys fs.FS, dir string, entries []string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.DirContainsf(t, fsys, dir, entries, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // DirExists checks whether a directory exists in the given path. It also fails
 // if the path is a file rather a directory or there is an error checking whether it exists.
 func DirExists(t TestingT, path string, msgAndArgs ...interface{}) {
@@ -87,6 +238,38 @@ func DirExistsf(t TestingT, path string, msg string, args ...interface{}) {
 	t.FailNow()
 }
 
+// DirTreeEqual asserts that the expected and actual file systems contain the
+// same files with the same content. It reports the files that were added,
+// removed and changed, with a diff of each changed file. Directories are only
+// compared through the files they contain, and file modes are ignored.
+//
+//	require.DirTreeEqual(t, os.DirFS("testdata/golden"), os.DirFS(outDir))
+func DirTreeEqual(t TestingT, expected fs.FS, actual fs.FS, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.DirTreeEqual(t, expected, actual, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// DirTreeEqualf asserts that the expected and actual file systems contain the
+// same files with the same content. It reports the files that were added,
+// removed and changed, with a diff of each changed file. Directories are only
+// compared through the files they contain, and file modes are ignored.
+//
+//	require.DirTreeEqualf(t, os.DirFS("testdata/golden"), os.DirFS(outDir), "error message %s", "formatted")
+func DirTreeEqualf(t TestingT, expected fs.FS, actual fs.FS, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.DirTreeEqualf(t, expected, actual, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // ElementsMatch asserts that the specified listA(array, slice...) is equal to specified
 // listB(array, slice...) ignoring the order of the elements. If there are duplicate elements,
 // the number of appearances of each of them in both lists should match.
@@ -250,6 +433,64 @@ func EqualExportedValuesf(t TestingT, expected interface{}, actual interface{},
 	t.FailNow()
 }
 
+// EqualFold asserts that two strings are equal under simple Unicode
+// case-folding, which is a more general form of case-insensitivity.
+//
+//	require.EqualFold(t, "Hello World", "hello world")
+func EqualFold(t TestingT, expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EqualFold(t, expected, actual, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EqualFoldf asserts that two strings are equal under simple Unicode
+// case-folding, which is a more general form of case-insensitivity.
+//
+//	require.EqualFoldf(t, "Hello World", "hello world", "error message %s", "formatted")
+func EqualFoldf(t TestingT, expected string, actual string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EqualFoldf(t, expected, actual, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EqualIgnoringWhitespace asserts that two strings are equal when all runs of
+// whitespace are considered equivalent and leading and trailing whitespace is
+// ignored.
+//
+//	require.EqualIgnoringWhitespace(t, "Hello World", "  Hello\n\tWorld ")
+func EqualIgnoringWhitespace(t TestingT, expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EqualIgnoringWhitespace(t, expected, actual, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EqualIgnoringWhitespacef asserts that two strings are equal when all runs of
+// whitespace are considered equivalent and leading and trailing whitespace is
+// ignored.
+//
+//	require.EqualIgnoringWhitespacef(t, "Hello World", "  Hello\n\tWorld ", "error message %s", "formatted")
+func EqualIgnoringWhitespacef(t TestingT, expected string, actual string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EqualIgnoringWhitespacef(t, expected, actual, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // EqualValues asserts that two objects are equal or convertible to the larger
 // type and equal.
 //
@@ -321,6 +562,44 @@ func ErrorAs(t TestingT, err error, target interface{}, msgAndArgs ...interface{
 	t.FailNow()
 }
 
+// ErrorAsType asserts that one of the errors in err's tree has the same type
+// as sample, and returns the first such error, searching depth first. sample is
+// only used for its type and is usually a nil pointer. As with errors.As, an
+// error in the tree matches if it has an As method that accepts the type.
+//
+//	pathErr, ok := require.ErrorAsType(t, err, (*fs.PathError)(nil))
+//	ok
+//	require.Equal(t, "config.yaml", pathErr.(*fs.PathError).Path)
+func ErrorAsType(t TestingT, err error, sample error, msgAndArgs ...interface{}) error {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.ErrorAsType(t, err, sample, msgAndArgs...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
+// ErrorAsTypef asserts that one of the errors in err's tree has the same type
+// as sample, and returns the first such error, searching depth first. sample is
+// only used for its type and is usually a nil pointer. As with errors.As, an
+// error in the tree matches if it has an As method that accepts the type.
+//
+//	pathErr, ok := require.ErrorAsTypef(t, err, (*fs.PathError)(nil), "error message %s", "formatted")
+//	ok
+//	require.Equal(t, "config.yaml", pathErr.(*fs.PathError).Path)
+func ErrorAsTypef(t TestingT, err error, sample error, msg string, args ...interface{}) error {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.ErrorAsTypef(t, err, sample, msg, args...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
 // ErrorAsf asserts that at least one of the errors in err's chain matches target, and if so, sets target to that error value.
 // This is a wrapper for errors.As.
 func ErrorAsf(t TestingT, err error, target interface{}, msg string, args ...interface{}) {
@@ -375,6 +654,66 @@ func ErrorIs(t TestingT, err error, target error, msgAndArgs ...interface{}) {
 	t.FailNow()
 }
 
+// ErrorIsAll asserts that each of targets matches at least one of the errors
+// in err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	require.ErrorIsAll(t, err, []error{io.EOF, fs.ErrClosed})
+func ErrorIsAll(t TestingT, err error, targets []error, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ErrorIsAll(t, err, targets, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ErrorIsAllf asserts that each of targets matches at least one of the errors
+// in err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	require.ErrorIsAllf(t, err, []error{io.EOF, fs.ErrClosed}, "error message %s", "formatted")
+func ErrorIsAllf(t TestingT, err error, targets []error, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ErrorIsAllf(t, err, targets, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ErrorIsAny asserts that at least one of targets matches one of the errors in
+// err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	require.ErrorIsAny(t, err, []error{context.Canceled, context.DeadlineExceeded})
+func ErrorIsAny(t TestingT, err error, targets []error, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ErrorIsAny(t, err, targets, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ErrorIsAnyf asserts that at least one of targets matches one of the errors in
+// err's tree, as built with errors.Join or any error that implements
+// Unwrap() []error.
+//
+//	require.ErrorIsAnyf(t, err, []error{context.Canceled, context.DeadlineExceeded}, "error message %s", "formatted")
+func ErrorIsAnyf(t TestingT, err error, targets []error, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ErrorIsAnyf(t, err, targets, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // ErrorIsf asserts that at least one of the errors in err's chain matches target.
 // This is a wrapper for errors.Is.
 func ErrorIsf(t TestingT, err error, target error, msg string, args ...interface{}) {
@@ -387,6 +726,36 @@ func ErrorIsf(t TestingT, err error, target error, msg string, args ...interface
 	t.FailNow()
 }
 
+// ErrorMatches asserts that err is not nil and that its message matches the
+// regexp rx.
+//
+//	require.ErrorMatches(t, err, `^open .*: no such file or directory$`)
+//	require.ErrorMatches(t, err, regexp.MustCompile(`status \d{3}`))
+func ErrorMatches(t TestingT, err error, rx interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ErrorMatches(t, err, rx, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ErrorMatchesf asserts that err is not nil and that its message matches the
+// regexp rx.
+//
+//	require.ErrorMatchesf(t, err, `^open .*: no such file or directory$`, "error message %s", "formatted")
+//	require.ErrorMatchesf(t, err, regexp.MustCompile(`status \d{3}`), "error message %s", "formatted")
+func ErrorMatchesf(t TestingT, err error, rx interface{}, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ErrorMatchesf(t, err, rx, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Errorf asserts that a function returned a non-nil error (ie. an error).
 //
 //	actualObj, err := SomeFunction()
@@ -415,6 +784,40 @@ func Eventually(t TestingT, condition func() bool, waitFor time.Duration, tick t
 	t.FailNow()
 }
 
+// EventuallyCtx asserts that given condition will be met in waitFor time,
+// periodically checking target function each tick. It stops early if ctx is
+// done. The condition is called with a context that is done once the
+// assertion gives up. The tick grows between checks as set by backoff, or is
+// constant if backoff is nil.
+//
+//	require.EventuallyCtx(t, ctx, func(ctx context.Context) bool { return true }, time.Second, 10*time.Millisecond, nil)
+func EventuallyCtx(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *assert.TickBackoff, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyCtx(t, ctx, condition, waitFor, tick, backoff, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyCtxf asserts that given condition will be met in waitFor time,
+// periodically checking target function each tick. It stops early if ctx is
+// done. The condition is called with a context that is done once the
+// assertion gives up. The tick grows between checks as set by backoff, or is
+// constant if backoff is nil.
+//
+//	require.EventuallyCtxf(t, ctx, func(ctx context.Context) bool { return true }, time.Second, 10*time.Millisecond, nil, "error message %s", "formatted")
+func EventuallyCtxf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *assert.TickBackoff, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyCtxf(t, ctx, condition, waitFor, tick, backoff, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // EventuallyWithT asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -443,6 +846,50 @@ func EventuallyWithT(t TestingT, condition func(collect *assert.CollectT), waitF
 	t.FailNow()
 }
 
+// EventuallyWithTCtx asserts that given condition will be met in waitFor
+// time, periodically checking target function each tick, like
+// EventuallyWithT. It stops early if ctx is done. The condition is called with
+// a context that is done once the assertion gives up. If the condition is not
+// met, the collected errors of the last tick are copied to t. The tick grows
+// between checks as set by backoff, or is constant if backoff is nil.
+//
+//	require.EventuallyWithTCtx(t, ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx)
+//		require.NoError(c, err)
+//		require.Equal(c, "ready", resp)
+//	}, 10*time.Second, 100*time.Millisecond, nil)
+func EventuallyWithTCtx(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *assert.CollectT), waitFor time.Duration, tick time.Duration, backoff *assert.TickBackoff, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithTCtx(t, ctx, condition, waitFor, tick, backoff, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// EventuallyWithTCtxf asserts that given condition will be met in waitFor
+// time, periodically checking target function each tick, like
+// EventuallyWithT. It stops early if ctx is done. The condition is called with
+// a context that is done once the assertion gives up. If the condition is not
+// met, the collected errors of the last tick are copied to t. The tick grows
+// between checks as set by backoff, or is constant if backoff is nil.
+//
+//	require.EventuallyWithTCtxf(t, ctx, func(ctx context.Context, c *assert.CollectT) {
+//		resp, err := client.Get(ctx)
+//		require.NoError(c, err)
+//		require.Equal(c, "ready", resp)
+//	}, 10*time.Second, 100*time.Millisecond, nil, "error message %s", "formatted")
+func EventuallyWithTCtxf(t TestingT, ctx context.Context, condition func(ctx context.Context, collect *assert.CollectT), waitFor time.Duration, tick time.Duration, backoff *assert.TickBackoff, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.EventuallyWithTCtxf(t, ctx, condition, waitFor, tick, backoff, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // EventuallyWithTf asserts that given condition will be met in waitFor time,
 // periodically checking target function each tick. In contrast to Eventually,
 // it supplies a CollectT to the condition function, so that the condition
@@ -581,6 +1028,64 @@ func Falsef(t TestingT, value bool, msg string, args ...interface{}) {
 	t.FailNow()
 }
 
+// FileContains asserts that the file name in fsys exists and that its content
+// contains the specified substring.
+//
+//	require.FileContains(t, os.DirFS("."), "go.mod", "module example.com/app")
+func FileContains(t TestingT, fsys fs.FS, name string, contains string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.FileContains(t, fsys, name, contains, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// FileContainsf asserts that the file name in fsys exists and that its content
+// contains the specified substring.
+//
+//	require.FileContainsf(t, os.DirFS("."), "go.mod", "module example.com/app", "error message %s", "formatted")
+func FileContainsf(t TestingT, fsys fs.FS, name string, contains string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.FileContainsf(t, fsys, name, contains, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// FileContentEquals asserts that the file name in fsys exists and that its
+// content is equal to expected. fsys can be any fs.FS, such as os.DirFS,
+// embed.FS or fstest.MapFS.
+//
+//	require.FileContentEquals(t, os.DirFS("testdata"), "golden.txt", output)
+func FileContentEquals(t TestingT, fsys fs.FS, name string, expected string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.FileContentEquals(t, fsys, name, expected, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// FileContentEqualsf asserts that the file name in fsys exists and that its
+// content is equal to expected. fsys can be any fs.FS, such as os.DirFS,
+// embed.FS or fstest.MapFS.
+//
+//	require.FileContentEqualsf(t, os.DirFS("testdata"), "golden.txt", output, "error message %s", "formatted")
+func FileContentEqualsf(t TestingT, fsys fs.FS, name string, expected string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.FileContentEqualsf(t, fsys, name, expected, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // FileExists checks whether a file exists in the given path. It also fails if
 // the path points to a directory or there is an error when trying to check the file.
 func FileExists(t TestingT, path string, msgAndArgs ...interface{}) {
@@ -605,6 +1110,34 @@ func FileExistsf(t TestingT, path string, msg string, args ...interface{}) {
 	t.FailNow()
 }
 
+// FileMode asserts that the file name in fsys exists and that its mode,
+// including the type bits, is equal to mode.
+//
+//	require.FileMode(t, os.DirFS("bin"), "run.sh", 0o755)
+func FileMode(t TestingT, fsys fs.FS, name string, mode fs.FileMode, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.FileMode(t, fsys, name, mode, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// FileModef asserts that the file name in fsys exists and that its mode,
+// including the type bits, is equal to mode.
+//
+//	require.FileModef(t, os.DirFS("bin"), "run.sh", 0o755, "error message %s", "formatted")
+func FileModef(t TestingT, fsys fs.FS, name string, mode fs.FileMode, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.FileModef(t, fsys, name, mode, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Greater asserts that the first element is greater than the second
 //
 //	require.Greater(t, 2, 1)
@@ -827,6 +1360,58 @@ func HTTPSuccessf(t TestingT, handler http.HandlerFunc, method string, url strin
 	t.FailNow()
 }
 
+// HasPrefix asserts that the specified string begins with prefix.
+//
+//	require.HasPrefix(t, "Hello World", "Hello")
+func HasPrefix(t TestingT, str string, prefix string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.HasPrefix(t, str, prefix, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// HasPrefixf asserts that the specified string begins with prefix.
+//
+//	require.HasPrefixf(t, "Hello World", "Hello", "error message %s", "formatted")
+func HasPrefixf(t TestingT, str string, prefix string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.HasPrefixf(t, str, prefix, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// HasSuffix asserts that the specified string ends with suffix.
+//
+//	require.HasSuffix(t, "Hello World", "World")
+func HasSuffix(t TestingT, str string, suffix string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.HasSuffix(t, str, suffix, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// HasSuffixf asserts that the specified string ends with suffix.
+//
+//	require.HasSuffixf(t, "Hello World", "World", "error message %s", "formatted")
+func HasSuffixf(t TestingT, str string, suffix string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.HasSuffixf(t, str, suffix, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Implements asserts that an object is implemented by the specified interface.
 //
 //	require.Implements(t, (*MyInterface)(nil), new(MyObject))
@@ -945,23 +1530,57 @@ func InEpsilonSlice(t TestingT, expected interface{}, actual interface{}, epsilo
 	t.FailNow()
 }
 
-// InEpsilonSlicef is the same as InEpsilon, except it compares each value from two slices.
-func InEpsilonSlicef(t TestingT, expected interface{}, actual interface{}, epsilon float64, msg string, args ...interface{}) {
+// InEpsilonSlicef is the same as InEpsilon, except it compares each value from two slices.
+func InEpsilonSlicef(t TestingT, expected interface{}, actual interface{}, epsilon float64, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.InEpsilonSlicef(t, expected, actual, epsilon, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// InEpsilonf asserts that expected and actual have a relative error less than epsilon
+func InEpsilonf(t TestingT, expected interface{}, actual interface{}, epsilon float64, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.InEpsilonf(t, expected, actual, epsilon, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// IsClosed asserts that the channel ch is closed and that no values are left
+// in its buffer. It does not wait, and values left in the buffer are not
+// consumed. Telling whether ch is closed takes a receive though: a value sent
+// at that moment, such as by a sender blocked on an unbuffered channel, is
+// consumed and reported as a failure.
+//
+//	require.IsClosed(t, done)
+func IsClosed(t TestingT, ch interface{}, msgAndArgs ...interface{}) {
 	if h, ok := t.(tHelper); ok {
 		h.Helper()
 	}
-	if assert.InEpsilonSlicef(t, expected, actual, epsilon, msg, args...) {
+	if assert.IsClosed(t, ch, msgAndArgs...) {
 		return
 	}
 	t.FailNow()
 }
 
-// InEpsilonf asserts that expected and actual have a relative error less than epsilon
-func InEpsilonf(t TestingT, expected interface{}, actual interface{}, epsilon float64, msg string, args ...interface{}) {
+// IsClosedf asserts that the channel ch is closed and that no values are left
+// in its buffer. It does not wait, and values left in the buffer are not
+// consumed. Telling whether ch is closed takes a receive though: a value sent
+// at that moment, such as by a sender blocked on an unbuffered channel, is
+// consumed and reported as a failure.
+//
+//	require.IsClosedf(t, done, "error message %s", "formatted")
+func IsClosedf(t TestingT, ch interface{}, msg string, args ...interface{}) {
 	if h, ok := t.(tHelper); ok {
 		h.Helper()
 	}
-	if assert.InEpsilonf(t, expected, actual, epsilon, msg, args...) {
+	if assert.IsClosedf(t, ch, msg, args...) {
 		return
 	}
 	t.FailNow()
@@ -1255,6 +1874,36 @@ func Lessf(t TestingT, e1 interface{}, e2 interface{}, msg string, args ...inter
 	t.FailNow()
 }
 
+// LinesMatch asserts that two strings are equal line by line. The opts
+// argument controls how lines are normalized before being compared, see
+// LinesIgnoreTrailingSpace and LinesNormalizeCRLF.
+//
+//	require.LinesMatch(t, "a\nb\n", "a  \r\nb\r\n", require.LinesIgnoreTrailingSpace|require.LinesNormalizeCRLF)
+func LinesMatch(t TestingT, expected string, actual string, opts assert.LineMatchOption, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.LinesMatch(t, expected, actual, opts, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// LinesMatchf asserts that two strings are equal line by line. The opts
+// argument controls how lines are normalized before being compared, see
+// LinesIgnoreTrailingSpace and LinesNormalizeCRLF.
+//
+//	require.LinesMatchf(t, "a\nb\n", "a  \r\nb\r\n", require.LinesIgnoreTrailingSpace|require.LinesNormalizeCRLF, "error message %s", "formatted")
+func LinesMatchf(t TestingT, expected string, actual string, opts assert.LineMatchOption, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.LinesMatchf(t, expected, actual, opts, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Negative asserts that the specified element is negative
 //
 //	require.Negative(t, -1)
@@ -1297,6 +1946,68 @@ func Never(t TestingT, condition func() bool, waitFor time.Duration, tick time.D
 	t.FailNow()
 }
 
+// NeverCtx asserts that the given condition doesn't satisfy in waitFor time,
+// periodically checking the target function each tick. It fails if ctx is
+// done before waitFor elapses. The condition is called with a context that is
+// done once the assertion stops checking. The tick grows between checks as set
+// by backoff, or is constant if backoff is nil.
+//
+//	require.NeverCtx(t, ctx, func(ctx context.Context) bool { return false }, time.Second, 10*time.Millisecond, nil)
+func NeverCtx(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *assert.TickBackoff, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverCtx(t, ctx, condition, waitFor, tick, backoff, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NeverCtxf asserts that the given condition doesn't satisfy in waitFor time,
+// periodically checking the target function each tick. It fails if ctx is
+// done before waitFor elapses. The condition is called with a context that is
+// done once the assertion stops checking. The tick grows between checks as set
+// by backoff, or is constant if backoff is nil.
+//
+//	require.NeverCtxf(t, ctx, func(ctx context.Context) bool { return false }, time.Second, 10*time.Millisecond, nil, "error message %s", "formatted")
+func NeverCtxf(t TestingT, ctx context.Context, condition func(ctx context.Context) bool, waitFor time.Duration, tick time.Duration, backoff *assert.TickBackoff, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverCtxf(t, ctx, condition, waitFor, tick, backoff, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NeverReceives asserts that no value is received from the channel ch, and
+// that it is not closed, during the given duration.
+//
+//	require.NeverReceives(t, errs, 100*time.Millisecond)
+func NeverReceives(t TestingT, ch interface{}, duration time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverReceives(t, ch, duration, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NeverReceivesf asserts that no value is received from the channel ch, and
+// that it is not closed, during the given duration.
+//
+//	require.NeverReceivesf(t, errs, 100*time.Millisecond, "error message %s", "formatted")
+func NeverReceivesf(t TestingT, ch interface{}, duration time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NeverReceivesf(t, ch, duration, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Neverf asserts that the given condition doesn't satisfy in waitFor time,
 // periodically checking the target function each tick.
 //
@@ -1415,6 +2126,80 @@ func NoFileExistsf(t TestingT, path string, msg string, args ...interface{}) {
 	t.FailNow()
 }
 
+// NoGoroutineLeaks asserts that all the goroutines started after snapshot was
+// taken stop running within waitFor, checking them each tick. Goroutines of
+// the runtime and of the testing package are ignored. If snapshot is nil,
+// every other goroutine is considered to be leaked.
+//
+//	snapshot := require.SnapshotGoroutines()
+//	server.Start()
+//	server.Stop()
+//	require.NoGoroutineLeaks(t, snapshot, time.Second, 10*time.Millisecond)
+func NoGoroutineLeaks(t TestingT, snapshot *assert.GoroutineSnapshot, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoGoroutineLeaks(t, snapshot, waitFor, tick, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NoGoroutineLeaksf asserts that all the goroutines started after snapshot was
+// taken stop running within waitFor, checking them each tick. Goroutines of
+// the runtime and of the testing package are ignored. If snapshot is nil,
+// every other goroutine is considered to be leaked.
+//
+//	snapshot := require.SnapshotGoroutines()
+//	server.Start()
+//	server.Stop()
+//	require.NoGoroutineLeaksf(t, snapshot, time.Second, 10*time.Millisecond, "error message %s", "formatted")
+func NoGoroutineLeaksf(t TestingT, snapshot *assert.GoroutineSnapshot, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NoGoroutineLeaksf(t, snapshot, waitFor, tick, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NotClosed asserts that the channel ch is not closed. A closed channel that
+// still has values in its buffer is not considered closed yet. It does not
+// wait, and values left in the buffer are not consumed. Telling whether ch is
+// closed takes a receive though: a value sent at that moment, such as by a
+// sender blocked on an unbuffered channel, is consumed and reported as a
+// failure, so NotClosed is meant for channels without pending senders.
+//
+//	require.NotClosed(t, done)
+func NotClosed(t TestingT, ch interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NotClosed(t, ch, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// NotClosedf asserts that the channel ch is not closed. A closed channel that
+// still has values in its buffer is not considered closed yet. It does not
+// wait, and values left in the buffer are not consumed. Telling whether ch is
+// closed takes a receive though: a value sent at that moment, such as by a
+// sender blocked on an unbuffered channel, is consumed and reported as a
+// failure, so NotClosedf is meant for channels without pending senders.
+//
+//	require.NotClosedf(t, done, "error message %s", "formatted")
+func NotClosedf(t TestingT, ch interface{}, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.NotClosedf(t, ch, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // NotContains asserts that the specified string, list(array, slice...) or map does NOT contain the
 // specified substring or element.
 //
@@ -1847,6 +2632,36 @@ func PanicsWithError(t TestingT, errString string, f assert.PanicTestFunc, msgAn
 	t.FailNow()
 }
 
+// PanicsWithErrorIs asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value is an error with target in its
+// tree, as reported by ErrorIs.
+//
+//	require.PanicsWithErrorIs(t, io.ErrUnexpectedEOF, func(){ GoCrazy() })
+func PanicsWithErrorIs(t TestingT, target error, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.PanicsWithErrorIs(t, target, f, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// PanicsWithErrorIsf asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value is an error with target in its
+// tree, as reported by ErrorIs.
+//
+//	require.PanicsWithErrorIsf(t, io.ErrUnexpectedEOF, func(){ GoCrazy() }, "error message %s", "formatted")
+func PanicsWithErrorIsf(t TestingT, target error, f assert.PanicTestFunc, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.PanicsWithErrorIsf(t, target, f, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // PanicsWithErrorf asserts that the code inside the specified PanicTestFunc
 // panics, and that the recovered panic value is an error that satisfies the
 // EqualError comparison.
@@ -1862,6 +2677,72 @@ func PanicsWithErrorf(t TestingT, errString string, f assert.PanicTestFunc, msg
 	t.FailNow()
 }
 
+// PanicsWithMatch asserts that the code inside the specified PanicTestFunc
+// panics, and that match returns true for the recovered panic value.
+//
+//	require.PanicsWithMatch(t, func(){ GoCrazy() }, func(recovered interface{}) bool {
+//		code, ok := recovered.(int)
+//		return ok && code >= 500
+//	})
+func PanicsWithMatch(t TestingT, f assert.PanicTestFunc, match func(recovered interface{}) bool, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.PanicsWithMatch(t, f, match, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// PanicsWithMatchf asserts that the code inside the specified PanicTestFunc
+// panics, and that match returns true for the recovered panic value.
+//
+//	require.PanicsWithMatchf(t, func(){ GoCrazy() }, func(recovered interface{}) bool {
+//		code, ok := recovered.(int)
+//		return ok && code >= 500
+//	}, "error message %s", "formatted")
+func PanicsWithMatchf(t TestingT, f assert.PanicTestFunc, match func(recovered interface{}) bool, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.PanicsWithMatchf(t, f, match, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// PanicsWithRegexp asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value matches the regexp rx. If the
+// value is an error, its message is matched; any other value is formatted
+// with %v.
+//
+//	require.PanicsWithRegexp(t, `^index out of range`, func(){ GoCrazy() })
+func PanicsWithRegexp(t TestingT, rx interface{}, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.PanicsWithRegexp(t, rx, f, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// PanicsWithRegexpf asserts that the code inside the specified PanicTestFunc
+// panics, and that the recovered panic value matches the regexp rx. If the
+// value is an error, its message is matched; any other value is formatted
+// with %v.
+//
+//	require.PanicsWithRegexpf(t, `^index out of range`, func(){ GoCrazy() }, "error message %s", "formatted")
+func PanicsWithRegexpf(t TestingT, rx interface{}, f assert.PanicTestFunc, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.PanicsWithRegexpf(t, rx, f, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // PanicsWithValue asserts that the code inside the specified PanicTestFunc panics, and that
 // the recovered panic value equals the expected panic value.
 //
@@ -1931,6 +2812,128 @@ func Positivef(t TestingT, e interface{}, msg string, args ...interface{}) {
 	t.FailNow()
 }
 
+// ReaderContains asserts that the content read from r contains the specified
+// string or []byte. r is read in chunks, so that large streams are never held
+// in memory as a whole, and reading stops as soon as contains is found.
+//
+//	require.ReaderContains(t, resp.Body, "<title>Home</title>")
+func ReaderContains(t TestingT, r io.Reader, contains interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReaderContains(t, r, contains, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ReaderContainsf asserts that the content read from r contains the specified
+// string or []byte. r is read in chunks, so that large streams are never held
+// in memory as a whole, and reading stops as soon as contains is found.
+//
+//	require.ReaderContainsf(t, resp.Body, "<title>Home</title>", "error message %s", "formatted")
+func ReaderContainsf(t TestingT, r io.Reader, contains interface{}, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReaderContainsf(t, r, contains, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ReaderEquals asserts that the content read from actual is equal to expected,
+// which can be a string, a []byte or an io.Reader. Both are read in chunks, so
+// that large streams are never held in memory as a whole. On failure, it shows
+// a hexdump of both around the first difference. Readers are not closed.
+//
+//	require.ReaderEquals(t, golden, resp.Body)
+func ReaderEquals(t TestingT, expected interface{}, actual io.Reader, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReaderEquals(t, expected, actual, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ReaderEqualsf asserts that the content read from actual is equal to expected,
+// which can be a string, a []byte or an io.Reader. Both are read in chunks, so
+// that large streams are never held in memory as a whole. On failure, it shows
+// a hexdump of both around the first difference. Readers are not closed.
+//
+//	require.ReaderEqualsf(t, golden, resp.Body, "error message %s", "formatted")
+func ReaderEqualsf(t TestingT, expected interface{}, actual io.Reader, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReaderEqualsf(t, expected, actual, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// Receives asserts that a value is received from the channel ch within
+// timeout. It returns the received value, and whether the assertion was
+// successful.
+//
+//	require.Receives(t, results, time.Second)
+func Receives(t TestingT, ch interface{}, timeout time.Duration, msgAndArgs ...interface{}) interface{} {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.Receives(t, ch, timeout, msgAndArgs...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
+// ReceivesValue asserts that a value is received from the channel ch within
+// timeout, and that it is equal to expected.
+//
+//	require.ReceivesValue(t, results, "done", time.Second)
+func ReceivesValue(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReceivesValue(t, ch, expected, timeout, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// ReceivesValuef asserts that a value is received from the channel ch within
+// timeout, and that it is equal to expected.
+//
+//	require.ReceivesValuef(t, results, "done", time.Second, "error message %s", "formatted")
+func ReceivesValuef(t TestingT, ch interface{}, expected interface{}, timeout time.Duration, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.ReceivesValuef(t, ch, expected, timeout, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// Receivesf asserts that a value is received from the channel ch within
+// timeout. It returns the received value, and whether the assertion was
+// successful.
+//
+//	require.Receivesf(t, results, time.Second, "error message %s", "formatted")
+func Receivesf(t TestingT, ch interface{}, timeout time.Duration, msg string, args ...interface{}) interface{} {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	value, ok := assert.Receivesf(t, ch, timeout, msg, args...)
+	if !ok {
+		t.FailNow()
+	}
+	return value
+}
+
 // Regexp asserts that a specified regexp matches a string.
 //
 //	require.Regexp(t, regexp.MustCompile("start"), "it's starting")
@@ -1945,6 +2948,62 @@ func Regexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface
 	t.FailNow()
 }
 
+// RegexpGroups asserts that a specified regexp matches a string, and that the
+// named capture groups of the first match have the expected values.
+//
+//	require.RegexpGroups(t, `(?P<key>\w+)=(?P<value>\w+)`, "level=debug", map[string]string{"key": "level", "value": "debug"})
+func RegexpGroups(t TestingT, rx interface{}, str interface{}, expectedGroups map[string]string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.RegexpGroups(t, rx, str, expectedGroups, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// RegexpGroupsf asserts that a specified regexp matches a string, and that the
+// named capture groups of the first match have the expected values.
+//
+//	require.RegexpGroupsf(t, `(?P<key>\w+)=(?P<value>\w+)`, "level=debug", map[string]string{"key": "level", "value": "debug"}, "error message %s", "formatted")
+func RegexpGroupsf(t TestingT, rx interface{}, str interface{}, expectedGroups map[string]string, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.RegexpGroupsf(t, rx, str, expectedGroups, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
+// RegexpMatchCount asserts that a specified regexp matches a string exactly
+// count times, without overlapping matches.
+//
+//	require.RegexpMatchCount(t, "ERROR", logOutput, 2)
+func RegexpMatchCount(t TestingT, rx interface{}, str interface{}, count int, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.RegexpMatchCount(t, rx, str, count, msgAndArgs...) {
+		return
+	}
+	t.FailNow()
+}
+
+// RegexpMatchCountf asserts that a specified regexp matches a string exactly
+// count times, without overlapping matches.
+//
+//	require.RegexpMatchCountf(t, "ERROR", logOutput, 2, "error message %s", "formatted")
+func RegexpMatchCountf(t TestingT, rx interface{}, str interface{}, count int, msg string, args ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if assert.RegexpMatchCountf(t, rx, str, count, msg, args...) {
+		return
+	}
+	t.FailNow()
+}
+
 // Regexpf asserts that a specified regexp matches a string.
 //
 //	require.Regexpf(t, regexp.MustCompile("start"), "it's starting", "error message %s", "formatted")
@@ -788,3 +788,17 @@ func TestEventuallyWithTTrue(t *testing.T) {
 	False(t, mockT.Failed, "Check should pass")
 	Equal(t, 2, counter, "Condition is expected to be called 2 times")
 }
+
+func TestReceives(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan int, 1)
+	ch <- 42
+	Equal(t, 42, Receives(t, ch, time.Second))
+
+	mockT := new(MockT)
+	Nil(t, Receives(mockT, ch, time.Millisecond))
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
@@ -64,3 +64,14 @@ type SetupSubTest interface {
 type TearDownSubTest interface {
 	TearDownSubTest()
 }
+
+// WithGoroutineLeakCheck has an IgnoreGoroutines method. Suites implementing
+// it fail when goroutines started by a test are still running after
+// TearDownTest, or when goroutines started by the suite are still running
+// after TearDownSuite. A goroutine is reported once, by the test that
+// leaked it. Goroutines started by parallel tests running at the same time
+// can be reported as well. IgnoreGoroutines returns the functions of
+// goroutines that are allowed to keep running, see assert.GoroutineSnapshot.
+type WithGoroutineLeakCheck interface {
+	IgnoreGoroutines() []string
+}
@@ -18,6 +18,23 @@ import (
 
 var matchMethod = flag.String("testify.m", "", "regular expression to select tests of the testify suite to run")
 
+// goroutineLeakWaitFor and goroutineLeakTick define how long suites
+// implementing WithGoroutineLeakCheck wait for goroutines to stop.
+var (
+	goroutineLeakWaitFor = time.Second
+	goroutineLeakTick    = 10 * time.Millisecond
+)
+
+// snapshotGoroutines returns a snapshot of the running goroutines if suite
+// implements WithGoroutineLeakCheck, nil otherwise.
+func snapshotGoroutines(suite TestingSuite) *assert.GoroutineSnapshot {
+	leakCheck, ok := suite.(WithGoroutineLeakCheck)
+	if !ok {
+		return nil
+	}
+	return assert.SnapshotGoroutines().IgnoreGoroutines(leakCheck.IgnoreGoroutines()...)
+}
+
 // Suite is a basic testing suite with methods for storing and
 // retrieving the current *testing.T context.
 type Suite struct {
@@ -138,6 +155,11 @@ func Run(t *testing.T, suite TestingSuite) {
 		stats = newSuiteInformation()
 	}
 
+	// The goroutines already reported by a test are removed from
+	// suiteGoroutines, so that they are reported only once.
+	suiteGoroutines := snapshotGoroutines(suite)
+	var suiteGoroutinesMu sync.Mutex
+
 	var tests []test
 	methodFinder := reflect.TypeOf(suite)
 	suiteName := methodFinder.Elem().Name()
@@ -180,6 +202,7 @@ func Run(t *testing.T, suite TestingSuite) {
 			run: func(t *testing.T) {
 				parentT := suite.T()
 				suite.SetT(t)
+				goroutines := snapshotGoroutines(suite)
 				defer recoverAndFailOnPanic(t)
 				defer func() {
 					t.Helper()
@@ -196,6 +219,13 @@ func Run(t *testing.T, suite TestingSuite) {
 						tearDownTestSuite.TearDownTest()
 					}
 
+					if goroutines != nil && !assert.NoGoroutineLeaks(t, goroutines, goroutineLeakWaitFor, goroutineLeakTick) {
+						// Don't report the leaked goroutines again after TearDownSuite.
+						suiteGoroutinesMu.Lock()
+						suiteGoroutines.IgnoreLeaked(goroutines)
+						suiteGoroutinesMu.Unlock()
+					}
+
 					suite.SetT(parentT)
 					failOnPanic(t, r)
 				}()
@@ -232,6 +262,10 @@ func Run(t *testing.T, suite TestingSuite) {
 			tearDownAllSuite.TearDownSuite()
 		}
 
+		if suiteGoroutines != nil {
+			assert.NoGoroutineLeaks(t, suiteGoroutines, goroutineLeakWaitFor, goroutineLeakTick)
+		}
+
 		if suiteWithStats, measureStats := suite.(WithStats); measureStats {
 			stats.End = time.Now()
 			suiteWithStats.HandleStats(suiteName, stats)
@@ -849,3 +849,55 @@ func TestSuiteSignatureValidation(t *testing.T) {
 	assert.True(t, suiteTester.setUp, "SetupSuite should have been executed")
 	assert.True(t, suiteTester.toreDown, "TearDownSuite should have been executed")
 }
+
+type goroutineLeakSuite struct {
+	Suite
+	leakInTest bool
+	stop       chan struct{}
+}
+
+func (s *goroutineLeakSuite) IgnoreGoroutines() []string {
+	return nil
+}
+
+func (s *goroutineLeakSuite) SetupTest() {
+	s.stop = make(chan struct{})
+}
+
+func (s *goroutineLeakSuite) TearDownTest() {
+	if !s.leakInTest {
+		close(s.stop)
+	}
+}
+
+func (s *goroutineLeakSuite) TestStartGoroutine() {
+	stop := s.stop
+	go func() {
+		<-stop
+	}()
+}
+
+func TestSuiteGoroutineLeakCheck(t *testing.T) {
+	defer func(waitFor time.Duration) {
+		goroutineLeakWaitFor = waitFor
+	}(goroutineLeakWaitFor)
+	goroutineLeakWaitFor = 50 * time.Millisecond
+
+	ok := testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/goroutineLeakSuite",
+		F: func(t *testing.T) {
+			Run(t, &goroutineLeakSuite{})
+		},
+	}})
+	assert.True(t, ok, "goroutines stopped in TearDownTest are not leaked")
+
+	leakingSuite := &goroutineLeakSuite{leakInTest: true}
+	ok = testing.RunTests(allTestsFilter, []testing.InternalTest{{
+		Name: t.Name() + "/leakingSuite",
+		F: func(t *testing.T) {
+			Run(t, leakingSuite)
+		},
+	}})
+	assert.False(t, ok, "goroutines still running after TearDownTest are leaked")
+	close(leakingSuite.stop)
+}